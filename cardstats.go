@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/cardstats"
+)
+
+// ServeAPICardStats serves the /api/cardstats/{format} endpoint: how each card in a format performed
+// over every finished draft, alongside its rating in the set file.
+func ServeAPICardStats(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	re := regexp.MustCompile(`/api/cardstats/([^/]+)`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
+	}
+	format := parseResult[1]
+	if !slices.Contains(formats(), format) {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	ratings, err := cardstats.ReadRatings(filepath.Join("sets", format+".json"))
+	if err != nil {
+		return err
+	}
+	tally, err := cardstats.Load(ob, format)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(tally.Stats(ratings))
+}
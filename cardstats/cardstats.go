@@ -0,0 +1,266 @@
+// Package cardstats measures how cards perform across the drafts of a format: how early they're taken,
+// how often they're taken when someone sees them, and how the decks that took them did.
+package cardstats
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/picklog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// Stats are how one card performed. Pick positions start at 1 for the first pick of a pack.
+type Stats struct {
+	// CardID is the card's ID in the set file. Cards without one are grouped by name.
+	CardID string `json:"cardId"`
+	Name   string `json:"name"`
+	// Rating is the card's rating in the set file, or nil if it doesn't have one.
+	Rating *float64 `json:"rating"`
+	// Seen counts every pick where the card was in the pack, and Taken counts the picks that took it.
+	Seen  int `json:"seen"`
+	Taken int `json:"taken"`
+	// TakenPick is the average pick the card was taken at.
+	TakenPick float64 `json:"takenPick"`
+	// LastSeenPick is the average of the last pick each drafter saw a copy at, whether or not they
+	// took it.
+	LastSeenPick float64 `json:"lastSeenPick"`
+	// PickRate is the share of the times the card was seen that it was taken.
+	PickRate float64 `json:"pickRate"`
+	// Wins, Losses and Draws are the match results of the drafters who took the card.
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	Draws   int     `json:"draws"`
+	WinRate float64 `json:"winRate"`
+}
+
+type cardTally struct {
+	name           string
+	seen           int
+	taken          int
+	takenPicks     int
+	lastSeenPicks  int
+	lastSeenCopies int
+	record         picklog.Record
+}
+
+// Tally adds up card stats one draft at a time.
+type Tally struct {
+	cards map[string]*cardTally
+}
+
+// NewTally returns an empty Tally.
+func NewTally() *Tally {
+	return &Tally{cards: make(map[string]*cardTally)}
+}
+
+// AddDraft counts every pick in a draft. records are the drafters' match results, keyed by user ID.
+func (t *Tally) AddDraft(draft *schema.Draft, records map[uint64]picklog.Record) error {
+	type seatCopy struct {
+		seat int
+		card uint64
+	}
+	lastSeen := make(map[seatCopy]int)
+	copyKeys := make(map[uint64]string)
+	keyFor := func(card *schema.Card) (string, error) {
+		if key, ok := copyKeys[card.Id]; ok {
+			return key, nil
+		}
+		name, err := picklog.CardName(card)
+		if err != nil {
+			return "", err
+		}
+		key := card.CardId
+		if key == "" {
+			key = name
+		}
+		if t.cards[key] == nil {
+			t.cards[key] = &cardTally{name: name}
+		}
+		copyKeys[card.Id] = key
+		return key, nil
+	}
+
+	var previous *picklog.Pick
+	for _, pick := range picklog.Reconstruct(draft) {
+		if pick.Seat == nil {
+			continue
+		}
+		// Both cards taken in one pick of a pick-two draft were seen from the same pack.
+		samePick := previous != nil && previous.Seat == pick.Seat && previous.Round == pick.Round &&
+			previous.Number == pick.Number
+		if !samePick {
+			for _, card := range pick.Pack {
+				key, err := keyFor(card)
+				if err != nil {
+					return err
+				}
+				t.cards[key].seen++
+				lastSeen[seatCopy{pick.Seat.Position, card.Id}] = pick.Number
+			}
+		}
+		previous = &pick
+
+		key, err := keyFor(pick.Card)
+		if err != nil {
+			return err
+		}
+		card := t.cards[key]
+		card.taken++
+		card.takenPicks += pick.Number
+		if pick.Seat.User != nil {
+			record := records[pick.Seat.User.Id]
+			card.record.Wins += record.Wins
+			card.record.Losses += record.Losses
+			card.record.Draws += record.Draws
+		}
+	}
+
+	for seen, number := range lastSeen {
+		card := t.cards[copyKeys[seen.card]]
+		card.lastSeenPicks += number
+		card.lastSeenCopies++
+	}
+	return nil
+}
+
+// Stats lists every card seen so far, taken earliest first. ratings are the set file's ratings by card
+// ID, and may be nil.
+func (t *Tally) Stats(ratings map[string]float64) []Stats {
+	stats := []Stats{}
+	for key, card := range t.cards {
+		cardStats := Stats{
+			CardID: key,
+			Name:   card.name,
+			Seen:   card.seen,
+			Taken:  card.taken,
+			Wins:   card.record.Wins,
+			Losses: card.record.Losses,
+			Draws:  card.record.Draws,
+		}
+		if rating, ok := ratings[key]; ok {
+			cardStats.Rating = &rating
+		}
+		if card.taken > 0 {
+			cardStats.TakenPick = float64(card.takenPicks) / float64(card.taken)
+		}
+		if card.lastSeenCopies > 0 {
+			cardStats.LastSeenPick = float64(card.lastSeenPicks) / float64(card.lastSeenCopies)
+		}
+		if card.seen > 0 {
+			cardStats.PickRate = float64(card.taken) / float64(card.seen)
+		}
+		if played := card.record.Wins + card.record.Losses + card.record.Draws; played > 0 {
+			cardStats.WinRate = float64(card.record.Wins) / float64(played)
+		}
+		stats = append(stats, cardStats)
+	}
+	slices.SortFunc(stats, func(a, b Stats) int {
+		// Cards that were never taken go last.
+		return cmp.Or(
+			cmp.Compare(min(b.Taken, 1), min(a.Taken, 1)),
+			cmp.Compare(a.TakenPick, b.TakenPick),
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.CardID, b.CardID),
+		)
+	})
+	return stats
+}
+
+// Load tallies every finished draft of a format.
+func Load(ob *objectbox.ObjectBox, format string) (*Tally, error) {
+	draftIDs, err := schema.BoxForDraft(ob).Query(schema.Draft_.Format.Equals(format, true)).FindIds()
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s drafts: %w", format, err)
+	}
+	tally := NewTally()
+	// Drafts are loaded one at a time so a big format doesn't hold every draft in memory.
+	for _, draftID := range draftIDs {
+		draft, err := schema.BoxForDraft(ob).Get(draftID)
+		if err != nil {
+			return nil, fmt.Errorf("error loading draft %d: %w", draftID, err)
+		}
+		if !lifecycle.State(draft.State).Finished() {
+			continue
+		}
+		records, err := picklog.LoadRecords(ob, draftID)
+		if err != nil {
+			return nil, fmt.Errorf("error loading results for draft %d: %w", draftID, err)
+		}
+		err = tally.AddDraft(draft, records)
+		if err != nil {
+			return nil, fmt.Errorf("error counting picks in draft %d: %w", draftID, err)
+		}
+	}
+	return tally, nil
+}
+
+// SetCard is a card's entry in a set file.
+type SetCard struct {
+	ID     string `json:"id"`
+	Rarity string `json:"rarity"`
+	// Rating is nil if the card doesn't have one.
+	Rating *float64 `json:"rating"`
+}
+
+// ReadSetCards reads the cards in a set file.
+func ReadSetCards(setFile string) ([]SetCard, error) {
+	byteValue, err := os.ReadFile(setFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading set file: %w", err)
+	}
+	var cfg struct {
+		Cards []SetCard `json:"cards"`
+	}
+	err = json.Unmarshal(byteValue, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing set file %s: %w", setFile, err)
+	}
+	return cfg.Cards, nil
+}
+
+// ReadRatings reads the rating of every card in a set file, keyed by card ID. Cards without a rating
+// are left out.
+func ReadRatings(setFile string) (map[string]float64, error) {
+	cards, err := ReadSetCards(setFile)
+	if err != nil {
+		return nil, err
+	}
+	ratings := make(map[string]float64)
+	for _, card := range cards {
+		if card.Rating != nil {
+			ratings[card.ID] = *card.Rating
+		}
+	}
+	return ratings, nil
+}
+
+// WriteReport writes stats as a table.
+func WriteReport(w io.Writer, stats []Stats) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, err := fmt.Fprintln(writer, "card\trating\tseen\ttaken\ttaken at\tlast seen at\tpick rate\trecord\twin rate\t")
+	if err != nil {
+		return err
+	}
+	for _, card := range stats {
+		rating := "-"
+		if card.Rating != nil {
+			rating = fmt.Sprintf("%.1f", *card.Rating)
+		}
+		_, err = fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%.2f\t%.2f\t%.0f%%\t%d-%d-%d\t%.0f%%\t\n",
+			card.Name, rating, card.Seen, card.Taken, card.TakenPick, card.LastSeenPick, 100*card.PickRate,
+			card.Wins, card.Losses, card.Draws, 100*card.WinRate)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
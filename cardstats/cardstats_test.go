@@ -0,0 +1,142 @@
+package cardstats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/walkingeyerobot/r38/picklog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+func makeCards(names ...string) []*schema.Card {
+	var cards []*schema.Card
+	for _, name := range names {
+		cards = append(cards, &schema.Card{
+			Id:     uint64(name[0]),
+			CardId: strings.ToLower(name),
+			Data:   fmt.Sprintf(`{"scryfall":{"name":"%s"}}`, name),
+		})
+	}
+	return cards
+}
+
+func TestTally(t *testing.T) {
+	cards := makeCards("A", "B", "C", "D", "E", "F")
+	pack1 := &schema.Pack{Id: 1, Round: 1, OriginalCards: cards[:3]}
+	pack2 := &schema.Pack{Id: 2, Round: 1, OriginalCards: cards[3:]}
+	alice := &schema.User{Id: 1}
+	bob := &schema.User{Id: 2}
+	draft := &schema.Draft{
+		Seats: []*schema.Seat{{Position: 0, User: alice}, {Position: 1, User: bob}},
+		// Each seat opens a pack, then they're passed back and forth.
+		Events: []*schema.Event{
+			{Id: 1, Position: 0, Round: 1, Pack: pack1, Card1: cards[0]},
+			{Id: 2, Position: 1, Round: 1, Pack: pack2, Card1: cards[3]},
+			{Id: 3, Position: 0, Round: 1, Pack: pack2, Card1: cards[4]},
+			{Id: 4, Position: 1, Round: 1, Pack: pack1, Card1: cards[1]},
+			{Id: 5, Position: 0, Round: 1, Pack: pack1, Card1: cards[2]},
+			{Id: 6, Position: 1, Round: 1, Pack: pack2, Card1: cards[5]},
+		},
+	}
+	records := map[uint64]picklog.Record{
+		1: {Wins: 2, Losses: 1},
+		2: {Losses: 2, Draws: 1},
+	}
+
+	tally := NewTally()
+	err := tally.AddDraft(draft, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := tally.Stats(map[string]float64{"a": 4.5})
+
+	var names []string
+	for _, card := range stats {
+		names = append(names, card.Name)
+	}
+	if strings.Join(names, "") != "ADBECF" {
+		t.Errorf("expected cards in the order they were taken, got %v", names)
+	}
+	a := stats[0]
+	if a.CardID != "a" || a.Rating == nil || *a.Rating != 4.5 || a.Seen != 1 || a.Taken != 1 || a.PickRate != 1 ||
+		a.TakenPick != 1 || a.LastSeenPick != 1 || a.Wins != 2 || a.Losses != 1 || a.WinRate != 2.0/3 {
+		t.Errorf("card A's stats are wrong: %+v", a)
+	}
+	c := stats[4]
+	// Alice saw C first and last, and Bob saw it in between.
+	if c.Rating != nil || c.Seen != 3 || c.PickRate != 1.0/3 || c.TakenPick != 3 || c.LastSeenPick != 2.5 {
+		t.Errorf("card C's stats are wrong: %+v", c)
+	}
+	b := stats[2]
+	if b.Seen != 2 || b.TakenPick != 2 || b.LastSeenPick != 1.5 || b.Losses != 2 || b.Draws != 1 || b.WinRate != 0 {
+		t.Errorf("card B's stats are wrong: %+v", b)
+	}
+}
+
+func TestTallyPickTwo(t *testing.T) {
+	cards := makeCards("A", "B", "C")
+	pack := &schema.Pack{Id: 1, Round: 1, OriginalCards: cards}
+	draft := &schema.Draft{
+		PickTwo: true,
+		Seats:   []*schema.Seat{{Position: 0, User: &schema.User{Id: 1}}},
+		Events: []*schema.Event{
+			{Id: 1, Position: 0, Round: 1, Pack: pack, Card1: cards[0], Card2: cards[1]},
+		},
+	}
+	tally := NewTally()
+	err := tally.AddDraft(draft, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, card := range tally.Stats(nil) {
+		if card.Seen != 1 {
+			t.Errorf("expected %s to be seen once when two cards are taken from its pack, got %d", card.Name, card.Seen)
+		}
+	}
+}
+
+func TestTallyRejectsBadCardData(t *testing.T) {
+	card := &schema.Card{Id: 1, Data: "not json"}
+	draft := &schema.Draft{
+		Seats: []*schema.Seat{{Position: 0}},
+		Events: []*schema.Event{
+			{Id: 1, Position: 0, Round: 1, Pack: &schema.Pack{Id: 1, OriginalCards: []*schema.Card{card}}, Card1: card},
+		},
+	}
+	err := NewTally().AddDraft(draft, nil)
+	if err == nil {
+		t.Errorf("expected an error for unreadable card data")
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	rating := 3.0
+	var report strings.Builder
+	err := WriteReport(&report, []Stats{{Name: "Card A", Rating: &rating, Seen: 2, Taken: 1, TakenPick: 1, LastSeenPick: 1, PickRate: 0.5, Wins: 2, Losses: 1, WinRate: 2.0 / 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "Card A") || !strings.Contains(lines[1], "3.0") ||
+		!strings.Contains(lines[1], "50%") || !strings.Contains(lines[1], "2-1-0") || !strings.Contains(lines[1], "67%") {
+		t.Errorf("report is wrong:\n%s", report.String())
+	}
+}
+
+func TestReadRatings(t *testing.T) {
+	ratings, err := ReadRatings("../sets/isd.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ratings) != 254 {
+		t.Errorf("expected every card in isd to have a rating, got %d", len(ratings))
+	}
+	ratings, err = ReadRatings("../sets/cube.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ratings) != 0 {
+		t.Errorf("expected no ratings for the cube, got %d", len(ratings))
+	}
+}
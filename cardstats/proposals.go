@@ -0,0 +1,270 @@
+package cardstats
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Proposal is a new rating for a card, based on how early it's taken.
+type Proposal struct {
+	CardID    string  `json:"cardId"`
+	Name      string  `json:"name"`
+	Rarity    string  `json:"rarity"`
+	Old       float64 `json:"old"`
+	New       float64 `json:"new"`
+	TakenPick float64 `json:"takenPick"`
+	Taken     int     `json:"taken"`
+}
+
+// Propose rates cards by how early they're actually taken. Within each rarity, the cards that were
+// taken at least minTaken times are ranked by their average pick and handed the ratings those same cards
+// already have, highest first. Every rarity keeps the same ratings, just on different cards, so limits
+// on the average rating of a pack's commons mean what they did before. Only changed ratings are
+// returned, biggest change first.
+func Propose(cards []SetCard, stats []Stats, minTaken int) []Proposal {
+	statsByID := make(map[string]Stats)
+	for _, card := range stats {
+		statsByID[card.CardID] = card
+	}
+	byRarity := make(map[string][]SetCard)
+	for _, card := range cards {
+		if card.Rating != nil && statsByID[card.ID].Taken >= max(minTaken, 1) {
+			byRarity[card.Rarity] = append(byRarity[card.Rarity], card)
+		}
+	}
+
+	var proposals []Proposal
+	for rarity, group := range byRarity {
+		var ratings []float64
+		for _, card := range group {
+			ratings = append(ratings, *card.Rating)
+		}
+		slices.SortFunc(ratings, func(a, b float64) int {
+			return cmp.Compare(b, a)
+		})
+		slices.SortFunc(group, func(a, b SetCard) int {
+			return cmp.Or(
+				cmp.Compare(statsByID[a.ID].TakenPick, statsByID[b.ID].TakenPick),
+				cmp.Compare(*b.Rating, *a.Rating),
+				strings.Compare(a.ID, b.ID),
+			)
+		})
+		for i, card := range group {
+			if ratings[i] == *card.Rating {
+				continue
+			}
+			proposals = append(proposals, Proposal{
+				CardID:    card.ID,
+				Name:      statsByID[card.ID].Name,
+				Rarity:    rarity,
+				Old:       *card.Rating,
+				New:       ratings[i],
+				TakenPick: statsByID[card.ID].TakenPick,
+				Taken:     statsByID[card.ID].Taken,
+			})
+		}
+	}
+	slices.SortFunc(proposals, func(a, b Proposal) int {
+		return cmp.Or(
+			cmp.Compare(math.Abs(b.New-b.Old), math.Abs(a.New-a.Old)),
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.CardID, b.CardID),
+		)
+	})
+	return proposals
+}
+
+// WriteProposals writes proposals as a diff of ratings for review.
+func WriteProposals(w io.Writer, proposals []Proposal) error {
+	for _, proposal := range proposals {
+		_, err := fmt.Fprintf(w, "%s (%s): %.1f -> %.1f, taken %d times at pick %.2f on average\n",
+			proposal.Name, proposal.Rarity, proposal.Old, proposal.New, proposal.Taken, proposal.TakenPick)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field is a key and value of a JSON object, kept in the order they appear.
+type field struct {
+	key   string
+	value json.RawMessage
+}
+
+// ApplyProposals rewrites a set file with the proposed ratings, both a card's own rating and the one in
+// its data. Everything else in the file, including the order of keys, is kept as it was.
+func ApplyProposals(setFile []byte, proposals []Proposal) ([]byte, error) {
+	newRatings := make(map[string]float64)
+	for _, proposal := range proposals {
+		newRatings[proposal.CardID] = proposal.New
+	}
+
+	cfg, err := decodeObject(setFile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing set file: %w", err)
+	}
+	cardsIndex := slices.IndexFunc(cfg, func(f field) bool { return f.key == "cards" })
+	if cardsIndex == -1 {
+		return nil, fmt.Errorf("set file has no cards")
+	}
+	var cards []json.RawMessage
+	err = json.Unmarshal(cfg[cardsIndex].value, &cards)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cards: %w", err)
+	}
+	for i, rawCard := range cards {
+		card, err := decodeObject(rawCard)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing card: %w", err)
+		}
+		var id string
+		if idIndex := slices.IndexFunc(card, func(f field) bool { return f.key == "id" }); idIndex != -1 {
+			err = json.Unmarshal(card[idIndex].value, &id)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing card id: %w", err)
+			}
+		}
+		rating, ok := newRatings[id]
+		if !ok {
+			continue
+		}
+		ratingIndex := slices.IndexFunc(card, func(f field) bool { return f.key == "rating" })
+		if ratingIndex == -1 {
+			return nil, fmt.Errorf("card %s has no rating to replace", id)
+		}
+		card[ratingIndex].value, err = json.Marshal(rating)
+		if err != nil {
+			return nil, err
+		}
+		// The client reads the rating from the card's data, which is JSON encoded as a string.
+		dataIndex := slices.IndexFunc(card, func(f field) bool { return f.key == "data" })
+		if dataIndex != -1 {
+			card[dataIndex].value, err = replaceDataRating(card[dataIndex].value, rating)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing rating in card %s data: %w", id, err)
+			}
+		}
+		cards[i], err = encodeObject(card)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cfg[cardsIndex].value = encodeArray(cards)
+
+	compact, err := encodeObject(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	err = json.Indent(&out, compact, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasSuffix(setFile, []byte("\n")) {
+		out.WriteString("\n")
+	}
+	return out.Bytes(), nil
+}
+
+// replaceDataRating replaces the rating in a card's data, a JSON object encoded as a JSON string.
+func replaceDataRating(rawData json.RawMessage, rating float64) (json.RawMessage, error) {
+	var data string
+	err := json.Unmarshal(rawData, &data)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := decodeObject([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+	ratingIndex := slices.IndexFunc(fields, func(f field) bool { return f.key == "rating" })
+	if ratingIndex == -1 {
+		return nil, fmt.Errorf("no rating to replace")
+	}
+	fields[ratingIndex].value, err = json.Marshal(rating)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeObject(fields)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
+
+func decodeObject(raw []byte) ([]field, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object, got %v", token)
+	}
+	var fields []field
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a key, got %v", token)
+		}
+		var value json.RawMessage
+		err = decoder.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field{key, value})
+	}
+	return fields, nil
+}
+
+// encodeArray joins values into a JSON array. Unlike json.Marshal, it leaves their contents exactly as
+// they were.
+func encodeArray(values []json.RawMessage) json.RawMessage {
+	var out bytes.Buffer
+	out.WriteString("[")
+	for i, value := range values {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		out.Write(value)
+	}
+	out.WriteString("]")
+	return out.Bytes()
+}
+
+func encodeObject(fields []field) (json.RawMessage, error) {
+	var out bytes.Buffer
+	out.WriteString("{")
+	for i, f := range fields {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(key)
+		out.WriteString(":")
+		out.Write(f.value)
+	}
+	out.WriteString("}")
+	return out.Bytes(), nil
+}
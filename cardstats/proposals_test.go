@@ -0,0 +1,117 @@
+package cardstats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func rating(r float64) *float64 {
+	return &r
+}
+
+func TestPropose(t *testing.T) {
+	cards := []SetCard{
+		{ID: "a", Rarity: "common", Rating: rating(1)},
+		{ID: "b", Rarity: "common", Rating: rating(2)},
+		{ID: "c", Rarity: "common", Rating: rating(3)},
+		{ID: "d", Rarity: "rare", Rating: rating(4)},
+		{ID: "e", Rarity: "rare", Rating: rating(5)},
+		{ID: "f", Rarity: "common", Rating: rating(4)},
+		{ID: "g", Rarity: "common"},
+	}
+	stats := []Stats{
+		{CardID: "a", Name: "A", Taken: 4, TakenPick: 2},
+		{CardID: "b", Name: "B", Taken: 4, TakenPick: 8},
+		{CardID: "c", Name: "C", Taken: 4, TakenPick: 5},
+		{CardID: "d", Name: "D", Taken: 4, TakenPick: 1},
+		{CardID: "e", Name: "E", Taken: 4, TakenPick: 3},
+		// Too few picks to go by.
+		{CardID: "f", Name: "F", Taken: 1, TakenPick: 14},
+		// No rating to replace.
+		{CardID: "g", Name: "G", Taken: 4, TakenPick: 1},
+	}
+
+	proposals := Propose(cards, stats, 2)
+	var summary []string
+	for _, proposal := range proposals {
+		summary = append(summary, fmt.Sprintf("%s:%g>%g", proposal.Name, proposal.Old, proposal.New))
+	}
+	// Commons keep the ratings 1, 2 and 3 and rares keep 4 and 5, ordered by how early they're taken.
+	if strings.Join(summary, " ") != "A:1>3 B:2>1 C:3>2 D:4>5 E:5>4" {
+		t.Errorf("unexpected proposals %v", summary)
+	}
+	if proposals[0].TakenPick != 2 || proposals[0].Taken != 4 || proposals[0].Rarity != "common" {
+		t.Errorf("proposal is missing its stats: %+v", proposals[0])
+	}
+}
+
+func TestApplyProposals(t *testing.T) {
+	for _, setFile := range []string{"../sets/isd.json", "../sets/cube.json"} {
+		original, err := os.ReadFile(setFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unchanged, err := ApplyProposals(original, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(unchanged, original) {
+			t.Errorf("expected %s to be rewritten exactly as it was", setFile)
+		}
+	}
+
+	original, err := os.ReadFile("../sets/isd.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards, err := ReadSetCards("../sets/isd.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := ApplyProposals(original, []Proposal{{CardID: cards[0].ID, Old: *cards[0].Rating, New: 4.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalLines := strings.Split(string(original), "\n")
+	updatedLines := strings.Split(string(updated), "\n")
+	if len(originalLines) != len(updatedLines) {
+		t.Fatalf("expected only a rating to change, got %d lines instead of %d", len(updatedLines), len(originalLines))
+	}
+	var changed []string
+	for i := range originalLines {
+		if originalLines[i] != updatedLines[i] {
+			changed = append(changed, updatedLines[i])
+		}
+	}
+	if len(changed) != 2 || strings.TrimSpace(changed[0]) != `"rating": 4.5,` {
+		t.Errorf("expected only the first card's rating and data to change, got %v", changed)
+	}
+	var updatedCfg struct {
+		Cards []struct {
+			Data string `json:"data"`
+		} `json:"cards"`
+	}
+	err = json.Unmarshal(updated, &updatedCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		Rating float64 `json:"rating"`
+	}
+	err = json.Unmarshal([]byte(updatedCfg.Cards[0].Data), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Rating != 4.5 {
+		t.Errorf("expected the rating in the first card's data to change, got %v", data.Rating)
+	}
+
+	_, err = ApplyProposals([]byte(`{"cards": [{"id": "a"}]}`), []Proposal{{CardID: "a", New: 1}})
+	if err == nil {
+		t.Errorf("expected an error adding a rating to a card without one")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+
+	"github.com/walkingeyerobot/r38/cardstats"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+func main() {
+	flagSet := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	setFile := flagSet.String("set", "sets/cube.json", "The set .json file whose format to report on.")
+	dbDir := flagSet.String("dbdir", "objectbox", "ObjectBox database directory.")
+	asJSON := flagSet.Bool("json", false, "If true, prints JSON instead of a table.")
+	propose := flagSet.Bool("propose", false,
+		"If true, prints new ratings for the set file based on how early cards are taken instead of the report.")
+	write := flagSet.Bool("write", false, "If true, writes the proposed ratings back into the set file.")
+	minTaken := flagSet.Int("min_taken", 3, "How many times a card must have been taken for its rating to be changed.")
+
+	err := flagSet.Parse(os.Args[1:])
+	if err != nil {
+		log.Printf("error parsing flags: %s", err.Error())
+		os.Exit(1)
+	}
+
+	format := strings.TrimSuffix(filepath.Base(*setFile), filepath.Ext(*setFile))
+	setCards, err := cardstats.ReadSetCards(*setFile)
+	if err != nil {
+		log.Printf("%s", err.Error())
+		os.Exit(1)
+	}
+	ratings, err := cardstats.ReadRatings(*setFile)
+	if err != nil {
+		log.Printf("%s", err.Error())
+		os.Exit(1)
+	}
+
+	ob, err := objectbox.NewBuilder().Model(schema.ObjectBoxModel()).
+		Directory(*dbDir).Build()
+	if err != nil {
+		log.Printf("error opening database: %s", err.Error())
+		os.Exit(1)
+	}
+	defer ob.Close()
+
+	var stats []cardstats.Stats
+	err = ob.RunInReadTx(func() error {
+		tally, err := cardstats.Load(ob, format)
+		if err != nil {
+			return err
+		}
+		stats = tally.Stats(ratings)
+		return nil
+	})
+	if err != nil {
+		log.Printf("%s", err.Error())
+		os.Exit(1)
+	}
+
+	if !*propose && !*write {
+		if *asJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(stats)
+		} else {
+			err = cardstats.WriteReport(os.Stdout, stats)
+		}
+		if err != nil {
+			log.Printf("error writing report: %s", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	proposals := cardstats.Propose(setCards, stats, *minTaken)
+	if len(ratings) == 0 {
+		log.Printf("%s has no ratings to rearrange", *setFile)
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(proposals)
+	} else {
+		err = cardstats.WriteProposals(os.Stdout, proposals)
+	}
+	if err != nil {
+		log.Printf("error writing proposals: %s", err.Error())
+		os.Exit(1)
+	}
+	if !*write || len(proposals) == 0 {
+		return
+	}
+
+	original, err := os.ReadFile(*setFile)
+	if err != nil {
+		log.Printf("error reading set file: %s", err.Error())
+		os.Exit(1)
+	}
+	updated, err := cardstats.ApplyProposals(original, proposals)
+	if err != nil {
+		log.Printf("error updating set file: %s", err.Error())
+		os.Exit(1)
+	}
+	err = os.WriteFile(*setFile, updated, 0o644)
+	if err != nil {
+		log.Printf("error writing set file: %s", err.Error())
+		os.Exit(1)
+	}
+	log.Printf("updated %d ratings in %s", len(proposals), *setFile)
+}
@@ -12,6 +12,15 @@ export interface SourceData {
   // If this source data is from the perspective of a specific player, then
   // that player's ID
   playerId?: number;
+
+  // The draft's latest draftModified value. Fetching with ?since=modified
+  // returns only the events after it, and null for packs that didn't change
+  modified?: number;
+  // Set on ?since= responses that contain only what changed
+  since?: number;
+  // Set on ?since= responses that contain the whole draft because the
+  // client's copy is out of date, e.g. after an undo
+  fullRefresh?: boolean;
 }
 
 export interface SourceSeat {
@@ -98,6 +107,8 @@ export interface BaseEvent {
   round: number;
   announcements: string[];
   draftModified: number;
+  // When the pick was made. Missing for shadow picks, and null for picks from before they were timed.
+  timestamp?: string | null;
   librarian: boolean;
 }
 
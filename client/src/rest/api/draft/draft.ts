@@ -9,6 +9,7 @@ export const ROUTE_DRAFT = endpoint({
   },
   queryVars: {
     as: 0,
-  } as { as?: number },
+    since: 0,
+  } as { as?: number; since?: number },
   response: {} as SourceData,
 });
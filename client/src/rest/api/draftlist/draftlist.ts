@@ -15,8 +15,9 @@ export interface HomeDraftDescriptor {
   id: number;
   name: string;
   availableSeats: number;
-  status: "joinable" | "reserved" | "member" | "spectator" | "closed";
+  status: "joinable" | "reserved" | "member" | "waitlist" | "waitlisted" | "spectator" | "closed";
   finished: boolean;
   joined: boolean;
+  waitlistLength: number;
   inPerson: boolean;
 }
@@ -0,0 +1,30 @@
+/** Sent by /api/draftupdates/:id whenever the draft changes. */
+export interface DraftUpdate {
+  draftId: number;
+  type: DraftUpdateType;
+  /** The draft's latest draftModified value once the change was made. */
+  modified: number;
+}
+
+export const DRAFT_UPDATE_TYPES = ["pick", "undo", "join", "leave", "result", "state"] as const;
+
+export type DraftUpdateType = (typeof DRAFT_UPDATE_TYPES)[number];
+
+/**
+ * Calls `onUpdate` whenever the server says the draft has changed. Returns a function that stops
+ * watching, or null if the browser can't watch for updates and the caller should poll instead.
+ */
+export function watchDraft(
+  draftId: number,
+  onUpdate: (update: DraftUpdate) => void,
+): (() => void) | null {
+  if (typeof EventSource == "undefined") {
+    return null;
+  }
+  const source = new EventSource(`/api/draftupdates/${draftId}`);
+  const listener = (e: MessageEvent<string>) => onUpdate(JSON.parse(e.data));
+  for (const type of DRAFT_UPDATE_TYPES) {
+    source.addEventListener(type, listener);
+  }
+  return () => source.close();
+}
@@ -1,16 +1,54 @@
 import { endpoint } from "@/rest/endpoint";
+import type { SourceUserInfo } from "@/rest/api/userinfo/userinfo";
 
 export const ROUTE_USER_STATS = endpoint({
   route: "/api/userstats/",
   method: "get",
   queryVars: {
     as: 0,
-  } as { as?: number },
+    user: 0,
+  } as { as?: number; user?: number },
   response: {} as SourceUserStats,
 });
 
 export interface SourceUserStats {
+  player: SourceUserInfo;
   activeDrafts: number;
   completedDrafts: number;
   draftedColors: number[];
+  matches: SourceMatchRecord;
+  matchesByColors: Record<string, SourceMatchRecord>;
+  matchesByFormat: Record<string, SourceMatchRecord>;
+  mostPicked: SourceCardCount[];
+  raresPicked: number;
+  rareAveragePick: number;
+  firstPickColors: number[];
+  trophies: number;
+  history: SourceUserDraftStats[];
+}
+
+export interface SourceMatchRecord {
+  wins: number;
+  losses: number;
+  draws: number;
+  winRate: number;
+}
+
+export interface SourceCardCount {
+  name: string;
+  count: number;
+}
+
+export interface SourceUserDraftStats {
+  draftId: number;
+  name: string;
+  format: string;
+  state: string;
+  colors: string;
+  picks: number;
+  wins: number;
+  losses: number;
+  draws: number;
+  byes: number;
+  trophy: boolean;
 }
@@ -0,0 +1,15 @@
+import { endpoint } from "@/rest/endpoint";
+import type { HomeDraftDescriptor } from "@/rest/api/draftlist/draftlist";
+
+export const ROUTE_WAITLIST = endpoint({
+  method: "post",
+  route: "/api/waitlist/",
+  queryVars: {
+    as: 0,
+  } as { as?: number },
+  bodyVars: {
+    id: 0 as number,
+    leave: undefined as boolean | undefined,
+  },
+  response: {} as HomeDraftDescriptor,
+});
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/picklog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ServeAPIExportPicks serves the /api/export/picks endpoint, a dataset of every pick in a draft, or in
+// every draft if there's no draft query parameter. Only drafts that are done picking are included.
+// The format query parameter chooses between csv and jsonl.
+func ServeAPIExportPicks(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	format := exportFormatName(r, "csv")
+	var contentType string
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	case "jsonl":
+		contentType = "application/jsonl"
+	default:
+		return fmt.Errorf("unknown dataset format %q", format)
+	}
+
+	var draftIDs []uint64
+	if draftParam := r.URL.Query().Get("draft"); draftParam != "" {
+		draftID, err := strconv.ParseUint(draftParam, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad draft id: %w", err)
+		}
+		draft, err := schema.BoxForDraft(ob).Get(draftID)
+		if err != nil {
+			return fmt.Errorf("error loading draft %d: %w", draftID, err)
+		}
+		if draft == nil {
+			return fmt.Errorf("couldn't find draft %d", draftID)
+		}
+		err = checkDraftState(draft, "export picks", lifecycle.State.Finished)
+		if err != nil {
+			return err
+		}
+		draftIDs = []uint64{draftID}
+	} else {
+		var err error
+		draftIDs, err = schema.BoxForDraft(ob).Query().FindIds()
+		if err != nil {
+			return fmt.Errorf("error listing drafts: %w", err)
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="picks.%s"`, format))
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		err := writer.Write(picklog.CSVHeader)
+		if err != nil {
+			return err
+		}
+		writer.Flush()
+	}
+
+	// Drafts are loaded one at a time so exporting everything doesn't hold every draft in memory.
+	for _, draftID := range draftIDs {
+		draft, err := schema.BoxForDraft(ob).Get(draftID)
+		if err != nil {
+			return fmt.Errorf("error loading draft %d: %w", draftID, err)
+		}
+		if !lifecycle.State(draft.State).Finished() {
+			continue
+		}
+		records, err := picklog.LoadRecords(ob, draftID)
+		if err != nil {
+			return fmt.Errorf("error loading results for draft %d: %w", draftID, err)
+		}
+		rows, err := picklog.Rows(draft, records)
+		if err != nil {
+			return fmt.Errorf("error reconstructing picks for draft %d: %w", draftID, err)
+		}
+		if format == "csv" {
+			err = picklog.WriteCSV(w, rows)
+		} else {
+			err = picklog.WriteJSONL(w, rows)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
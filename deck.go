@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// MinimumDeckSize is the fewest cards, basic lands included, a registered main deck can have.
+const MinimumDeckSize = 40
+
+// DeckError is returned when a submitted deck breaks the deck building rules.
+var DeckError = fmt.Errorf("invalid deck")
+
+// BasicLandNames are the basic lands players can add to their deck in any number.
+var BasicLandNames = []string{"Plains", "Island", "Swamp", "Mountain", "Forest"}
+
+// ServeAPIDeck serves the /api/deck endpoint.
+// GET returns the user's deck for a draft, and POST saves it, locking it in if asked.
+func ServeAPIDeck(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	re := regexp.MustCompile(`/api/deck/(\d+)`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
+	}
+	draftID, err := strconv.ParseInt(parseResult[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad api url: %w", err)
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftID))
+	if err != nil {
+		return fmt.Errorf("error loading draft %d: %w", draftID, err)
+	}
+	if draft == nil {
+		return fmt.Errorf("couldn't find draft %d", draftID)
+	}
+	seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+		return seat.User != nil && seat.User.Id == uint64(userID)
+	})
+	if seatIndex == -1 {
+		return fmt.Errorf("user %d isn't in draft %d", userID, draftID)
+	}
+	seat := draft.Seats[seatIndex]
+
+	deck, err := getDeck(ob, seat)
+	if err != nil {
+		return fmt.Errorf("error loading deck for seat %d: %w", seat.Id, err)
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		bodyBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("error reading post body: %w", err)
+		}
+		var posted PostedDeck
+		err = json.Unmarshal(bodyBytes, &posted)
+		if err != nil {
+			return fmt.Errorf("error parsing post body: %w", err)
+		}
+		deck, err = saveDeck(ob, draft, seat, deck, posted)
+		if err != nil {
+			return fmt.Errorf("error saving deck for seat %d: %w", seat.Id, err)
+		}
+	default:
+		return MethodNotAllowedError
+	}
+
+	return json.NewEncoder(w).Encode(deckToJSON(seat, deck))
+}
+
+// getDeck returns the deck registered for a seat, or nil if there isn't one yet.
+func getDeck(ob *objectbox.ObjectBox, seat *schema.Seat) (*schema.Deck, error) {
+	decks, err := schema.BoxForDeck(ob).Query(schema.Deck_.Seat.Equals(seat.Id)).Find()
+	if err != nil || len(decks) == 0 {
+		return nil, err
+	}
+	return decks[0], nil
+}
+
+// saveDeck checks a submitted deck against the seat's pool and stores it.
+// Decks can be changed until they're locked, which has to happen before any match results come in.
+func saveDeck(ob *objectbox.ObjectBox, draft *schema.Draft, seat *schema.Seat, deck *schema.Deck, posted PostedDeck) (*schema.Deck, error) {
+	err := checkDraftState(draft, "register a deck", func(state lifecycle.State) bool {
+		return state == lifecycle.Drafting || state == lifecycle.Deckbuilding || state == lifecycle.Playing
+	})
+	if err != nil {
+		return nil, err
+	}
+	results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draft.Id)).Count()
+	if err != nil {
+		return nil, err
+	}
+	if results > 0 {
+		return nil, fmt.Errorf("%w: can't register a deck in draft %d after round 1 has started reporting results",
+			DraftStateError, draft.Id)
+	}
+	if seat.Round < 4 {
+		return nil, fmt.Errorf("%w: seat %d hasn't finished drafting", DeckError, seat.Id)
+	}
+	if deck == nil {
+		deck = &schema.Deck{Seat: seat}
+	} else if deck.Locked {
+		return nil, fmt.Errorf("%w: deck for seat %d is already locked", DeckError, seat.Id)
+	}
+
+	// Every card comes out of the pool once; whatever isn't in the main deck is sideboard.
+	sideboard := slices.Clone(seat.PickedCards)
+	mainDeck := []*schema.Card{}
+	for _, cardID := range posted.MainDeck {
+		i := slices.IndexFunc(sideboard, func(card *schema.Card) bool {
+			return card.Id == uint64(cardID)
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("%w: card %d isn't in seat %d's pool", DeckError, cardID, seat.Id)
+		}
+		mainDeck = append(mainDeck, sideboard[i])
+		sideboard = slices.Delete(sideboard, i, i+1)
+	}
+	deck.MainDeck = mainDeck
+	deck.Sideboard = sideboard
+
+	basics := deckBasics(deck)
+	for _, count := range basics {
+		*count = 0
+	}
+	for name, count := range posted.Basics {
+		basic, ok := basics[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q isn't a basic land", DeckError, name)
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("%w: can't have %d %s", DeckError, count, name)
+		}
+		*basic = count
+	}
+
+	if posted.Lock {
+		size := len(deck.MainDeck)
+		for _, count := range basics {
+			size += *count
+		}
+		if size < MinimumDeckSize {
+			return nil, fmt.Errorf("%w: main deck has %d cards, but needs at least %d",
+				DeckError, size, MinimumDeckSize)
+		}
+		deck.Locked = true
+		deck.LockedAt = time.Now()
+	}
+
+	_, err = schema.BoxForDeck(ob).Put(deck)
+	if err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// deckBasics maps each basic land name to the deck's count of it.
+func deckBasics(deck *schema.Deck) map[string]*int {
+	return map[string]*int{
+		"Plains":   &deck.Plains,
+		"Island":   &deck.Islands,
+		"Swamp":    &deck.Swamps,
+		"Mountain": &deck.Mountains,
+		"Forest":   &deck.Forests,
+	}
+}
+
+// deckToJSON describes a seat's deck to the client. Seats without a deck get their whole pool as sideboard.
+func deckToJSON(seat *schema.Seat, deck *schema.Deck) DeckJSON {
+	deckJson := DeckJSON{
+		MainDeck:  []int64{},
+		Sideboard: []int64{},
+		Basics:    make(map[string]int),
+	}
+	if deck == nil {
+		for _, card := range seat.PickedCards {
+			deckJson.Sideboard = append(deckJson.Sideboard, int64(card.Id))
+		}
+		for _, name := range BasicLandNames {
+			deckJson.Basics[name] = 0
+		}
+		return deckJson
+	}
+
+	for _, card := range deck.MainDeck {
+		deckJson.MainDeck = append(deckJson.MainDeck, int64(card.Id))
+	}
+	for _, card := range deck.Sideboard {
+		deckJson.Sideboard = append(deckJson.Sideboard, int64(card.Id))
+	}
+	for name, count := range deckBasics(deck) {
+		deckJson.Basics[name] = *count
+	}
+	deckJson.Locked = deck.Locked
+	return deckJson
+}
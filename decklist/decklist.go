@@ -0,0 +1,97 @@
+// Package decklist renders drafted pools and decks into formats that other programs can import.
+package decklist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrUnknownFormat is returned by Lookup for formats that don't exist.
+var ErrUnknownFormat = errors.New("unknown decklist format")
+
+// Card is everything the formats need to know about a card.
+type Card struct {
+	Name            string
+	Set             string
+	CollectorNumber string
+	MtgoID          int64
+	Basic           bool
+}
+
+// Deck is a main deck and sideboard. A pool without a registered deck is all main deck.
+type Deck struct {
+	Name      string
+	Main      []Card
+	Sideboard []Card
+}
+
+// Entry is a card and how many copies of it are in one part of a deck.
+type Entry struct {
+	Card
+	Quantity int
+}
+
+// Rendered is a deck in some format, along with any cards the format had no way to include.
+type Rendered struct {
+	Data    []byte
+	Missing []Card
+}
+
+// Format is a way of writing out a deck.
+type Format struct {
+	Name        string
+	Extension   string
+	ContentType string
+	Render      func(deck Deck) (Rendered, error)
+}
+
+// Formats lists every supported format. Add new formats here.
+var Formats = []Format{
+	{Name: "text", Extension: "txt", ContentType: "text/plain; charset=utf-8", Render: renderText},
+	{Name: "arena", Extension: "txt", ContentType: "text/plain; charset=utf-8", Render: renderArena},
+	{Name: "cockatrice", Extension: "cod", ContentType: "application/xml", Render: renderCockatrice},
+	{Name: "mtgo", Extension: "dek", ContentType: "application/xml", Render: renderMtgo},
+}
+
+// Lookup finds a format by name.
+func Lookup(name string) (Format, error) {
+	for _, format := range Formats {
+		if format.Name == name {
+			return format, nil
+		}
+	}
+	return Format{}, fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+}
+
+// Group counts copies of cards, in the order each first appears. Cards with the same key are copies.
+func Group(cards []Card, key func(Card) string) []Entry {
+	var entries []Entry
+	index := make(map[string]int)
+	for _, card := range cards {
+		k := key(card)
+		i, ok := index[k]
+		if !ok {
+			i = len(entries)
+			index[k] = i
+			entries = append(entries, Entry{Card: card})
+		}
+		entries[i].Quantity++
+	}
+	return entries
+}
+
+// ByName treats cards with the same name as copies.
+func ByName(card Card) string {
+	return card.Name
+}
+
+// ByPrinting treats cards from the same set with the same collector number as copies.
+func ByPrinting(card Card) string {
+	return card.Name + "\x00" + card.Set + "\x00" + card.CollectorNumber
+}
+
+// ByMtgoID treats cards with the same MTGO catalog id as copies.
+func ByMtgoID(card Card) string {
+	return strconv.FormatInt(card.MtgoID, 10)
+}
@@ -0,0 +1,88 @@
+package decklist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testDeck = Deck{
+	Name: "test deck",
+	Main: []Card{
+		{Name: "Lightning Bolt", Set: "m10", CollectorNumber: "146", MtgoID: 31755},
+		{Name: "Lightning Bolt", Set: "m10", CollectorNumber: "146", MtgoID: 31755},
+		{Name: "Lightning Bolt", Set: "2x2", CollectorNumber: "117"},
+		{Name: "Mountain", Basic: true},
+	},
+	Sideboard: []Card{
+		{Name: "Smash to Smithereens", Set: "ala", CollectorNumber: "117", MtgoID: 29927},
+	},
+}
+
+func render(t *testing.T, name string) Rendered {
+	format, err := Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, err := format.Render(testDeck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rendered
+}
+
+func TestLookupRejectsUnknownFormat(t *testing.T) {
+	_, err := Lookup("magic workstation")
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestTextGroupsByName(t *testing.T) {
+	expected := "3 Lightning Bolt\n1 Mountain\n\n1 Smash to Smithereens\n"
+	if got := string(render(t, "text").Data); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestArenaKeepsPrintingsApart(t *testing.T) {
+	expected := "Deck\n2 Lightning Bolt (M10) 146\n1 Lightning Bolt (2X2) 117\n1 Mountain\n\n" +
+		"Sideboard\n1 Smash to Smithereens (ALA) 117\n"
+	if got := string(render(t, "arena").Data); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestCockatriceZones(t *testing.T) {
+	got := string(render(t, "cockatrice").Data)
+	for _, want := range []string{
+		"<deckname>test deck</deckname>",
+		`<zone name="main">`,
+		`<card number="3" name="Lightning Bolt"></card>`,
+		`<zone name="side">`,
+		`<card number="1" name="Smash to Smithereens"></card>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %s", want, got)
+		}
+	}
+}
+
+func TestMtgoReportsCardsWithoutIds(t *testing.T) {
+	rendered := render(t, "mtgo")
+	got := string(rendered.Data)
+	for _, want := range []string{
+		`<Cards CatID="31755" Quantity="2" Sideboard="false" Name="Lightning Bolt" />`,
+		`<Cards CatID="29927" Quantity="1" Sideboard="true" Name="Smash to Smithereens" />`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %s", want, got)
+		}
+	}
+	if strings.Contains(got, "Mountain") {
+		t.Errorf("basic lands shouldn't be exported to MTGO: %s", got)
+	}
+	if len(rendered.Missing) != 1 || rendered.Missing[0].Set != "2x2" {
+		t.Errorf("expected the 2X2 Lightning Bolt to be missing, got %v", rendered.Missing)
+	}
+}
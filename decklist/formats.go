@@ -0,0 +1,123 @@
+package decklist
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// renderText writes "4 Card Name" lines, with the sideboard after a blank line.
+func renderText(deck Deck) (Rendered, error) {
+	var sb strings.Builder
+	for _, entry := range Group(deck.Main, ByName) {
+		_, _ = fmt.Fprintf(&sb, "%d %s\n", entry.Quantity, entry.Name)
+	}
+	if len(deck.Sideboard) > 0 {
+		sb.WriteString("\n")
+		for _, entry := range Group(deck.Sideboard, ByName) {
+			_, _ = fmt.Fprintf(&sb, "%d %s\n", entry.Quantity, entry.Name)
+		}
+	}
+	return Rendered{Data: []byte(sb.String())}, nil
+}
+
+// renderArena writes MTG Arena's import format, which names the printing of each card.
+func renderArena(deck Deck) (Rendered, error) {
+	var sb strings.Builder
+	writeSection := func(header string, cards []Card) {
+		sb.WriteString(header + "\n")
+		for _, entry := range Group(cards, ByPrinting) {
+			if entry.Basic || entry.Set == "" {
+				_, _ = fmt.Fprintf(&sb, "%d %s\n", entry.Quantity, entry.Name)
+			} else {
+				_, _ = fmt.Fprintf(&sb, "%d %s (%s) %s\n",
+					entry.Quantity, entry.Name, strings.ToUpper(entry.Set), entry.CollectorNumber)
+			}
+		}
+	}
+	writeSection("Deck", deck.Main)
+	if len(deck.Sideboard) > 0 {
+		sb.WriteString("\n")
+		writeSection("Sideboard", deck.Sideboard)
+	}
+	return Rendered{Data: []byte(sb.String())}, nil
+}
+
+type cockatriceDeck struct {
+	XMLName  xml.Name         `xml:"cockatrice_deck"`
+	Version  int              `xml:"version,attr"`
+	DeckName string           `xml:"deckname"`
+	Comments string           `xml:"comments"`
+	Zones    []cockatriceZone `xml:"zone"`
+}
+
+type cockatriceZone struct {
+	Name  string           `xml:"name,attr"`
+	Cards []cockatriceCard `xml:"card"`
+}
+
+type cockatriceCard struct {
+	Number int    `xml:"number,attr"`
+	Name   string `xml:"name,attr"`
+}
+
+// renderCockatrice writes a Cockatrice .cod file.
+func renderCockatrice(deck Deck) (Rendered, error) {
+	zone := func(name string, cards []Card) cockatriceZone {
+		z := cockatriceZone{Name: name}
+		for _, entry := range Group(cards, ByName) {
+			z.Cards = append(z.Cards, cockatriceCard{Number: entry.Quantity, Name: entry.Name})
+		}
+		return z
+	}
+	cod := cockatriceDeck{
+		Version:  1,
+		DeckName: deck.Name,
+		Zones:    []cockatriceZone{zone("main", deck.Main), zone("side", deck.Sideboard)},
+	}
+
+	data, err := xml.MarshalIndent(cod, "", "    ")
+	if err != nil {
+		return Rendered{}, err
+	}
+	return Rendered{Data: append([]byte(xml.Header), append(data, '\n')...)}, nil
+}
+
+const dekHeader = `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<NetDeckID>0</NetDeckID>
+<PreconstructedDeckID>0</PreconstructedDeckID>
+`
+
+// renderMtgo writes an MTGO .dek file. MTGO only knows cards by catalog id, so cards without one are
+// reported as missing. Basic lands are left out for players to add in MTGO.
+func renderMtgo(deck Deck) (Rendered, error) {
+	var rendered Rendered
+	var sb strings.Builder
+	sb.WriteString(dekHeader)
+	writeSection := func(cards []Card, sideboard bool) {
+		var known []Card
+		for _, card := range cards {
+			if card.Basic {
+				continue
+			}
+			if card.MtgoID == 0 {
+				rendered.Missing = append(rendered.Missing, card)
+				continue
+			}
+			known = append(known, card)
+		}
+		for _, entry := range Group(known, ByMtgoID) {
+			var name bytes.Buffer
+			_ = xml.EscapeText(&name, []byte(entry.Name))
+			_, _ = fmt.Fprintf(&sb, "<Cards CatID=\"%d\" Quantity=\"%d\" Sideboard=\"%t\" Name=\"%s\" />\n",
+				entry.MtgoID, entry.Quantity, sideboard, name.String())
+		}
+	}
+	writeSection(deck.Main, false)
+	writeSection(deck.Sideboard, true)
+	sb.WriteString("</Deck>\n")
+	rendered.Data = []byte(sb.String())
+	return rendered, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+var DraftStateError = fmt.Errorf("draft state violation")
+
+// draftLifecycleHook is called after a draft changes state, inside the same transaction.
+type draftLifecycleHook func(ob *objectbox.ObjectBox, draft *schema.Draft, transition lifecycle.Transition) error
+
+// draftLifecycleHooks are run in order after every draft state transition.
+// Anything that should happen when a draft reaches a new state (notifications, pairings)
+// belongs here rather than at the call site that triggered the transition.
+var draftLifecycleHooks []draftLifecycleHook
+
+func init() {
+	// Hooks may themselves move the draft along, so they're set up here to avoid an
+	// initialization cycle.
+	draftLifecycleHooks = []draftLifecycleHook{
+		announceDraftOpenHook,
+		notifyDraftStartedHook,
+		notifyEndOfDraftHook,
+		queueDraftStateUpdateHook,
+	}
+}
+
+// transitionDraft moves a draft to a new lifecycle state, saves it and runs the lifecycle hooks.
+// Archiving a draft that's already archived does nothing.
+func transitionDraft(ob *objectbox.ObjectBox, draft *schema.Draft, to lifecycle.State) error {
+	from := lifecycle.State(draft.State)
+	if from == lifecycle.Archived && to == lifecycle.Archived {
+		return nil
+	}
+	err := lifecycle.Validate(from, to)
+	if err != nil {
+		return fmt.Errorf("%w: draft %d: %w", DraftStateError, draft.Id, err)
+	}
+
+	draft.State = string(to)
+	draft.Archived = to == lifecycle.Archived
+	if to == lifecycle.Drafting {
+		draft.StartedAt = time.Now()
+	}
+	_, err = schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		return fmt.Errorf("error moving draft %d to %s: %w", draft.Id, to, err)
+	}
+	log.Printf("draft %d moved from %s to %s", draft.Id, from, to)
+
+	transition := lifecycle.Transition{
+		DraftID: draft.Id,
+		From:    from,
+		To:      to,
+	}
+	for _, hook := range draftLifecycleHooks {
+		err = hook(ob, draft, transition)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTimeSet reports whether a time loaded from ObjectBox was ever set.
+// Dates added to an entity after it was stored load as the Unix epoch rather than the zero time.
+func isTimeSet(t time.Time) bool {
+	return !t.IsZero() && t.Unix() != 0
+}
+
+// checkDraftState returns a DraftStateError if the draft isn't in a state allowed by check.
+func checkDraftState(draft *schema.Draft, action string, check func(lifecycle.State) bool) error {
+	if !check(lifecycle.State(draft.State)) {
+		return fmt.Errorf("%w: can't %s in draft %d while it is %s", DraftStateError, action, draft.Id, draft.State)
+	}
+	return nil
+}
+
+// startDraft opens a draft for picking, whether or not every seat has been filled.
+func startDraft(ob *objectbox.ObjectBox, draftID int64) error {
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftID))
+	if err != nil {
+		return fmt.Errorf("error loading draft %d: %w", draftID, err)
+	}
+	if draft == nil {
+		return fmt.Errorf("couldn't find draft %d", draftID)
+	}
+	return transitionDraft(ob, draft, lifecycle.Drafting)
+}
+
+// notifyDraftStartedHook pings every drafter in an online draft once picks are unlocked.
+func notifyDraftStartedHook(_ *objectbox.ObjectBox, draft *schema.Draft, transition lifecycle.Transition) error {
+	if transition.To != lifecycle.Drafting || draft.InPerson {
+		return nil
+	}
+	for _, seat := range draft.Seats {
+		if seat.User != nil && seat.User.DiscordId != "" {
+			err := NotifyByDraftAndDiscordID(int64(draft.Id), seat.User.DiscordId)
+			if err != nil {
+				log.Printf("error notifying user %d of draft %d starting: %s", seat.User.Id, draft.Id, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// notifyEndOfDraftHook notifies everyone that cares once the last pick of a draft is made.
+func notifyEndOfDraftHook(ob *objectbox.ObjectBox, draft *schema.Draft, transition lifecycle.Transition) error {
+	if transition.To != lifecycle.Deckbuilding {
+		return nil
+	}
+	err := NotifyEndOfDraft(ob, int64(draft.Id))
+	if err != nil {
+		log.Printf("error notifying end of draft: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/decklist"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ServeAPIExport serves the /api/export endpoint, which returns the user's pool, or registered deck,
+// in the decklist format named by the format query parameter.
+func ServeAPIExport(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/export/(\d+)`)
+	if err != nil {
+		return err
+	}
+	format, err := decklist.Lookup(exportFormatName(r, "text"))
+	if err != nil {
+		return err
+	}
+	seat, rendered, err := renderUserExport(ob, draft, userID, format)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`,
+		safeFilename(exportUsername(seat.User)), format.Extension))
+	_, err = w.Write(rendered.Data)
+	return err
+}
+
+// ServeAPIExportDek serves the /api/export/dek endpoint, which returns the user's pool as an MTGO .dek file.
+// The number of cards left out for lack of an MTGO ID is sent in the X-Missing-Mtgo-Ids header.
+func ServeAPIExportDek(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/export/dek/(\d+)`)
+	if err != nil {
+		return err
+	}
+	format, err := decklist.Lookup("mtgo")
+	if err != nil {
+		return err
+	}
+	seat, rendered, err := renderUserExport(ob, draft, userID, format)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`,
+		safeFilename(exportUsername(seat.User)), format.Extension))
+	w.Header().Set("X-Missing-Mtgo-Ids", strconv.Itoa(len(rendered.Missing)))
+	_, err = w.Write(rendered.Data)
+	return err
+}
+
+// renderUserExport renders the pool, or registered deck, of the user's seat in a draft.
+func renderUserExport(ob *objectbox.ObjectBox, draft *schema.Draft, userID int64, format decklist.Format) (*schema.Seat, decklist.Rendered, error) {
+	seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+		return seat.User != nil && seat.User.Id == uint64(userID)
+	})
+	if seatIndex == -1 {
+		return nil, decklist.Rendered{}, fmt.Errorf("user %d isn't in draft %d", userID, draft.Id)
+	}
+	seat := draft.Seats[seatIndex]
+	rendered, err := renderSeatExport(ob, draft, seat, format)
+	if err != nil {
+		return nil, decklist.Rendered{}, fmt.Errorf("error exporting pool for user %d: %w", userID, err)
+	}
+	return seat, rendered, nil
+}
+
+// renderSeatExport renders a seat's pool, or registered deck, in format.
+func renderSeatExport(ob *objectbox.ObjectBox, draft *schema.Draft, seat *schema.Seat, format decklist.Format) (decklist.Rendered, error) {
+	deck, err := makeDecklist(ob, draft, seat)
+	if err != nil {
+		return decklist.Rendered{}, err
+	}
+	rendered, err := format.Render(deck)
+	if err != nil {
+		return decklist.Rendered{}, fmt.Errorf("error rendering %s export: %w", format.Name, err)
+	}
+	if len(rendered.Missing) > 0 {
+		log.Printf("%d cards in seat %d's pool can't be exported as %s", len(rendered.Missing), seat.Id, format.Name)
+	}
+	return rendered, nil
+}
+
+// ServeAPIExportDekZip serves the /api/export/dekzip endpoint, which returns a zip of every player's .dek file.
+// Cards left out for lack of an MTGO ID are listed in missing-mtgo-ids.txt inside the zip.
+func ServeAPIExportDekZip(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if userID != 1 {
+		return fmt.Errorf("not allowed")
+	}
+	draft, err := getDraftFromPath(ob, r, `/api/export/dekzip/(\d+)`)
+	if err != nil {
+		return err
+	}
+	format, err := decklist.Lookup("mtgo")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	var missingLines []string
+	for _, seat := range draft.Seats {
+		if seat.User == nil {
+			continue
+		}
+		rendered, err := renderSeatExport(ob, draft, seat, format)
+		if err != nil {
+			return fmt.Errorf("error exporting pool for user %d: %w", seat.User.Id, err)
+		}
+		username := exportUsername(seat.User)
+		// Players can share a name, so each file is numbered by seat.
+		file, err := zipWriter.Create(fmt.Sprintf("%d %s.%s", seat.Position+1, safeFilename(username), format.Extension))
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(rendered.Data)
+		if err != nil {
+			return err
+		}
+		for _, card := range rendered.Missing {
+			missingLines = append(missingLines, fmt.Sprintf("%s: %s", username, card.Name))
+		}
+	}
+	if len(missingLines) > 0 {
+		file, err := zipWriter.Create("missing-mtgo-ids.txt")
+		if err != nil {
+			return err
+		}
+		_, err = file.Write([]byte(strings.Join(missingLines, "\n") + "\n"))
+		if err != nil {
+			return err
+		}
+	}
+	err = zipWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s decks.zip"`, safeFilename(draft.Name)))
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// exportFormatName returns the format query parameter, or fallback if there isn't one.
+func exportFormatName(r *http.Request, fallback string) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	return fallback
+}
+
+// getDraftFromPath loads the draft whose id is the first submatch of pattern in the request path.
+func getDraftFromPath(ob *objectbox.ObjectBox, r *http.Request, pattern string) (*schema.Draft, error) {
+	re := regexp.MustCompile(pattern)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return nil, fmt.Errorf("bad api url")
+	}
+	draftID, err := strconv.ParseInt(parseResult[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad api url: %w", err)
+	}
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftID))
+	if err != nil {
+		return nil, fmt.Errorf("error loading draft %d: %w", draftID, err)
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("couldn't find draft %d", draftID)
+	}
+	return draft, nil
+}
+
+// makeDecklist collects a seat's cards for export. If the player registered a deck, it's split into
+// main deck and sideboard, basic lands included; otherwise the whole pool goes in the main deck.
+func makeDecklist(ob *objectbox.ObjectBox, draft *schema.Draft, seat *schema.Seat) (decklist.Deck, error) {
+	deck := decklist.Deck{Name: fmt.Sprintf("%s - %s", draft.Name, exportUsername(seat.User))}
+
+	mainDeck := seat.PickedCards
+	var sideboard []*schema.Card
+	registered, err := getDeck(ob, seat)
+	if err != nil {
+		return deck, err
+	}
+	if registered != nil {
+		mainDeck = registered.MainDeck
+		sideboard = registered.Sideboard
+	}
+
+	deck.Main, err = decklistCards(mainDeck)
+	if err != nil {
+		return deck, err
+	}
+	deck.Sideboard, err = decklistCards(sideboard)
+	if err != nil {
+		return deck, err
+	}
+	if registered != nil {
+		basics := deckBasics(registered)
+		for _, name := range BasicLandNames {
+			for range *basics[name] {
+				deck.Main = append(deck.Main, decklist.Card{Name: name, Basic: true})
+			}
+		}
+	}
+	return deck, nil
+}
+
+// decklistCards pulls what the decklist formats need out of each card's data.
+func decklistCards(cards []*schema.Card) ([]decklist.Card, error) {
+	var ret []decklist.Card
+	for _, card := range cards {
+		var cardData R38CardData
+		err := json.Unmarshal([]byte(card.Data), &cardData)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing data for card %d: %w", card.Id, err)
+		}
+		ret = append(ret, decklist.Card{
+			Name:            cardData.Scryfall.Name,
+			Set:             cardData.Scryfall.Set,
+			CollectorNumber: cardData.Scryfall.CollectorNumber,
+			MtgoID:          cardData.MTGO,
+		})
+	}
+	return ret, nil
+}
+
+// exportUsername is the name a player's exports are filed under, preferring their MTGO name.
+func exportUsername(user *schema.User) string {
+	if user.MtgoName != "" {
+		return user.MtgoName
+	}
+	return user.DiscordName
+}
+
+// safeFilename replaces anything but letters, digits, underscores and spaces so a name can be used as a filename.
+func safeFilename(name string) string {
+	return regexp.MustCompile(`[^\w\s]+`).ReplaceAllString(name, "-")
+}
@@ -176,6 +176,7 @@ function doParse(client, objstr) {
           playerModified: event.playerModified,
           position: event.position,
           round: event.round,
+          timestamp: event.timestamp,
           type: 'SecretPick'
         });
 
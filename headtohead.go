@@ -0,0 +1,240 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// discordMentionPattern matches a user mention in a Discord message.
+var discordMentionPattern = regexp.MustCompile(`<@!?(\d+)>`)
+
+// ServeAPIHeadToHead serves the /api/headtohead endpoint. /api/headtohead/{opponent} is the current
+// user's record against opponent, and /api/headtohead/{player}/{opponent} is anyone's.
+func ServeAPIHeadToHead(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	re := regexp.MustCompile(`/api/headtohead/(\d+)(?:/(\d+))?`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
+	}
+	ids := []string{strconv.FormatInt(userID, 10), parseResult[1]}
+	if len(parseResult[2]) > 0 {
+		ids = parseResult[1:]
+	}
+	var users []*schema.User
+	for _, id := range ids {
+		parsedID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad api url: %w", err)
+		}
+		user, err := schema.BoxForUser(ob).Get(parsedID)
+		if err != nil {
+			return fmt.Errorf("error loading user %d: %w", parsedID, err)
+		}
+		if user == nil {
+			return fmt.Errorf("no user %d", parsedID)
+		}
+		users = append(users, user)
+	}
+	if users[0].Id == users[1].Id {
+		return fmt.Errorf("user %d can't play themselves", users[0].Id)
+	}
+
+	headToHead, err := getHeadToHead(ob, users[0], users[1])
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(headToHead)
+}
+
+// getHeadToHead collects every match two players have played against each other.
+func getHeadToHead(ob *objectbox.ObjectBox, player *schema.User, opponent *schema.User) (HeadToHead, error) {
+	headToHead := HeadToHead{
+		Player:   makeUserInfo(player),
+		Opponent: makeUserInfo(opponent),
+		Drafts:   []HeadToHeadDraft{},
+	}
+
+	pairingRows, err := schema.BoxForPairing(ob).Query(objectbox.Any(
+		objectbox.All(schema.Pairing_.Player1.Equals(player.Id), schema.Pairing_.Player2.Equals(opponent.Id)),
+		objectbox.All(schema.Pairing_.Player1.Equals(opponent.Id), schema.Pairing_.Player2.Equals(player.Id)),
+	)).Find()
+	if err != nil {
+		return headToHead, fmt.Errorf("error loading pairings: %w", err)
+	}
+	matches := make(map[uint64][]HeadToHeadMatch)
+	for _, pairing := range pairingRows {
+		if pairing.Status != ResultConfirmed || pairing.Draft == nil {
+			continue
+		}
+		match := HeadToHeadMatch{
+			Round:      pairing.Round,
+			GamesWon:   pairing.Wins1,
+			GamesLost:  pairing.Wins2,
+			GamesDrawn: pairing.Draws,
+		}
+		if pairing.Player1.Id == opponent.Id {
+			match.GamesWon, match.GamesLost = match.GamesLost, match.GamesWon
+		}
+		matches[pairing.Draft.Id] = append(matches[pairing.Draft.Id], match)
+	}
+
+	drafts, err := schema.BoxForDraft(ob).Query(
+		schema.Draft_.Seats.Link(schema.Seat_.User.Equals(player.Id)),
+	).Find()
+	if err != nil {
+		return headToHead, fmt.Errorf("error loading drafts for user %d: %w", player.Id, err)
+	}
+	slices.SortFunc(drafts, func(a, b *schema.Draft) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	for _, draft := range drafts {
+		playerSeat := findSeat(draft, player)
+		opponentSeat := findSeat(draft, opponent)
+		if playerSeat == nil || opponentSeat == nil {
+			continue
+		}
+		draftMatches := matches[draft.Id]
+		if len(draftMatches) == 0 {
+			continue
+		}
+		slices.SortFunc(draftMatches, func(a, b HeadToHeadMatch) int {
+			return cmp.Compare(a.Round, b.Round)
+		})
+
+		draftHeadToHead := HeadToHeadDraft{
+			DraftID: int64(draft.Id),
+			Name:    draft.Name,
+			Format:  draft.Format,
+			Matches: draftMatches,
+		}
+		// Decks stay secret from opponents until every match has been played.
+		if lifecycle.State(draft.State).Concluded() {
+			playerDeck, err := headToHeadDeck(ob, playerSeat)
+			if err != nil {
+				return headToHead, err
+			}
+			opponentDeck, err := headToHeadDeck(ob, opponentSeat)
+			if err != nil {
+				return headToHead, err
+			}
+			draftHeadToHead.PlayerDeck = &playerDeck
+			draftHeadToHead.OpponentDeck = &opponentDeck
+		}
+		for _, match := range draftMatches {
+			switch {
+			case match.GamesWon > match.GamesLost:
+				headToHead.Wins++
+			case match.GamesWon < match.GamesLost:
+				headToHead.Losses++
+			default:
+				headToHead.Draws++
+			}
+			headToHead.GameWins += match.GamesWon
+			headToHead.GameLosses += match.GamesLost
+			headToHead.GameDraws += match.GamesDrawn
+		}
+		headToHead.Drafts = append(headToHead.Drafts, draftHeadToHead)
+	}
+	return headToHead, nil
+}
+
+func findSeat(draft *schema.Draft, user *schema.User) *schema.Seat {
+	index := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+		return seat.User != nil && seat.User.Id == user.Id
+	})
+	if index == -1 {
+		return nil
+	}
+	return draft.Seats[index]
+}
+
+func headToHeadDeck(ob *objectbox.ObjectBox, seat *schema.Seat) (HeadToHeadDeck, error) {
+	deck, err := getDeck(ob, seat)
+	if err != nil {
+		return HeadToHeadDeck{}, fmt.Errorf("error loading deck for seat %d: %w", seat.Id, err)
+	}
+	cards := seat.PickedCards
+	if deck != nil {
+		cards = deck.MainDeck
+	}
+	headToHeadDeck := HeadToHeadDeck{
+		Colors:     mainColors(cards),
+		Registered: deck != nil,
+		Cards:      []string{},
+	}
+	for _, card := range cards {
+		headToHeadDeck.Cards = append(headToHeadDeck.Cards, cardData(card).Scryfall.Name)
+	}
+	return headToHeadDeck, nil
+}
+
+// AnswerHeadToHead replies to "!h2h @opponent" with the author's record against opponent, and to
+// "!h2h @player @opponent" with player's.
+func AnswerHeadToHead(ob *objectbox.ObjectBox, channelID string, authorDiscordID string, content string) error {
+	discordIDs := []string{authorDiscordID}
+	for _, mention := range discordMentionPattern.FindAllStringSubmatch(content, -1) {
+		discordIDs = append(discordIDs, mention[1])
+	}
+	if len(discordIDs) == 3 {
+		discordIDs = discordIDs[1:]
+	}
+	if len(discordIDs) != 2 || discordIDs[0] == discordIDs[1] {
+		return DiscordNotify(channelID, "Usage: `!h2h @opponent` or `!h2h @player @opponent`")
+	}
+
+	var users []*schema.User
+	for _, discordID := range discordIDs {
+		found, err := schema.BoxForUser(ob).Query(schema.User_.DiscordId.Equals(discordID, true)).Find()
+		if err != nil {
+			return fmt.Errorf("error finding user %s: %w", discordID, err)
+		}
+		if len(found) == 0 {
+			return DiscordNotify(channelID, fmt.Sprintf("<@%s> hasn't drafted with us yet.", discordID))
+		}
+		users = append(users, found[0])
+	}
+	headToHead, err := getHeadToHead(ob, users[0], users[1])
+	if err != nil {
+		return err
+	}
+	return DiscordNotify(channelID, formatHeadToHead(headToHead, users[0], users[1]))
+}
+
+// formatHeadToHead describes a head-to-head record for a Discord message.
+func formatHeadToHead(headToHead HeadToHead, player *schema.User, opponent *schema.User) string {
+	if len(headToHead.Drafts) == 0 {
+		return fmt.Sprintf("%s and %s haven't played each other yet.", discordMention(player), discordMention(opponent))
+	}
+	lines := []string{fmt.Sprintf("%s vs %s: %s in matches, %s in games", discordMention(player),
+		discordMention(opponent), formatScore(headToHead.Wins, headToHead.Losses, headToHead.Draws),
+		formatScore(headToHead.GameWins, headToHead.GameLosses, headToHead.GameDraws))}
+	for _, draft := range headToHead.Drafts {
+		var matches []string
+		for _, match := range draft.Matches {
+			outcome := "drew"
+			if match.GamesWon > match.GamesLost {
+				outcome = "won"
+			} else if match.GamesWon < match.GamesLost {
+				outcome = "lost"
+			}
+			matches = append(matches, fmt.Sprintf("%s %s in round %d", outcome,
+				formatScore(match.GamesWon, match.GamesLost, match.GamesDrawn), match.Round))
+		}
+		name := fmt.Sprintf("**%s**", draft.Name)
+		if draft.PlayerDeck != nil && draft.OpponentDeck != nil {
+			name += fmt.Sprintf(" (%s vs %s)", draft.PlayerDeck.Colors, draft.OpponentDeck.Colors)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", name, strings.Join(matches, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
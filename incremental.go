@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// packSlot is a seat position and a zero-based round: where a pack sits in DraftJSON.
+type packSlot struct {
+	position int
+	round    int
+}
+
+// GetIncrementalJSON returns what changed in a draft's filtered replay data after the client fetched
+// it at DraftModified value since: the new events, every seat's metadata and the packs whose
+// contents changed, with null in place of the packs that didn't. The same filtering as
+// GetFilteredJSON applies. If the client's copy can't be brought up to date, the whole draft is
+// returned with fullRefresh set.
+func GetIncrementalJSON(ob *objectbox.ObjectBox, draftId int64, userId int64, since int64) (string, error) {
+	storedDraft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+	if err != nil {
+		return "", fmt.Errorf("error getting draft %d: %w", draftId, err)
+	}
+	if storedDraft == nil {
+		return "", fmt.Errorf("couldn't find draft %d", draftId)
+	}
+
+	draft, access, err := getReplay(ob, draftId, userId, time.Now())
+	if err != nil {
+		return "", err
+	}
+	fullReplay := access != filteredReplay
+
+	// The client's copy is from the future or includes a pick that's since been undone. Copies from
+	// before the undone pick, like those of spectators kept behind, never saw it.
+	undone := since >= int64(storedDraft.UndonePickModified) && since < int64(storedDraft.UndoneModified)
+	if since > draft.Modified || undone {
+		draft.FullRefresh = true
+		return filterDraftJSON(draft, userId, fullReplay)
+	}
+
+	// Rebuild what the client already has, so filtering can be compared before and after.
+	previous := draft
+	previous.Events = slices.DeleteFunc(slices.Clone(draft.Events), func(event DraftEvent) bool {
+		return event.DraftModified > since
+	})
+	if access == delayedReplay {
+		hideUnopenedPacks(&previous)
+	}
+	previousJSON, err := filterDraftJSON(previous, userId, fullReplay)
+	if err != nil {
+		return "", err
+	}
+	currentJSON, err := filterDraftJSON(draft, userId, fullReplay)
+	if err != nil || currentJSON == "" {
+		return currentJSON, err
+	}
+
+	var changedSlots map[packSlot]bool
+	if storedDraft.InPerson {
+		changedSlots = newEventPackSlots(storedDraft, since)
+	}
+	return diffDraftJSON(previousJSON, currentJSON, since, changedSlots)
+}
+
+// newEventPackSlots returns where the packs picked from after since sit. Packs are handed out to
+// seats part way through an in-person draft, so these are sent even if filtering leaves them as they
+// were.
+func newEventPackSlots(draft *schema.Draft, since int64) map[packSlot]bool {
+	packSlots := make(map[uint64]packSlot)
+	for _, seat := range draft.Seats {
+		for _, pack := range seat.OriginalPacks {
+			packSlots[pack.Id] = packSlot{position: seat.Position, round: pack.Round - 1}
+		}
+	}
+	slots := make(map[packSlot]bool)
+	for _, event := range draft.Events {
+		if int64(event.Modified) <= since || event.Pack == nil {
+			continue
+		}
+		if slot, ok := packSlots[event.Pack.Id]; ok {
+			slots[slot] = true
+		}
+	}
+	return slots
+}
+
+// diffDraftJSON trims the json for a draft down to what changed since the client's copy, which is
+// previousJSON. If an event the client has isn't in the current json, it's sent in full with
+// fullRefresh set instead.
+func diffDraftJSON(previousJSON string, currentJSON string, since int64, changedSlots map[packSlot]bool) (string, error) {
+	var previous, current map[string]json.RawMessage
+	err := json.Unmarshal([]byte(previousJSON), &previous)
+	if err != nil {
+		return "", fmt.Errorf("error parsing previous draft json: %w", err)
+	}
+	err = json.Unmarshal([]byte(currentJSON), &current)
+	if err != nil {
+		return "", fmt.Errorf("error parsing draft json: %w", err)
+	}
+
+	var previousEvents, currentEvents []json.RawMessage
+	err = json.Unmarshal(previous["events"], &previousEvents)
+	if err != nil {
+		return "", fmt.Errorf("error parsing previous draft events: %w", err)
+	}
+	err = json.Unmarshal(current["events"], &currentEvents)
+	if err != nil {
+		return "", fmt.Errorf("error parsing draft events: %w", err)
+	}
+	previousSet := make(map[string]bool)
+	for _, event := range previousEvents {
+		previousSet[string(event)] = true
+	}
+	currentSet := make(map[string]bool)
+	newEvents := []json.RawMessage{}
+	for _, event := range currentEvents {
+		currentSet[string(event)] = true
+		if !previousSet[string(event)] {
+			newEvents = append(newEvents, event)
+		}
+	}
+	for event := range previousSet {
+		if !currentSet[event] {
+			// Filtering rewrote history the client has already seen.
+			current["fullRefresh"] = json.RawMessage("true")
+			ret, err := json.Marshal(current)
+			if err != nil {
+				return "", fmt.Errorf("error marshalling draft json: %w", err)
+			}
+			return string(ret), nil
+		}
+	}
+
+	var previousSeats, currentSeats []map[string]json.RawMessage
+	err = json.Unmarshal(previous["seats"], &previousSeats)
+	if err != nil {
+		return "", fmt.Errorf("error parsing previous draft seats: %w", err)
+	}
+	err = json.Unmarshal(current["seats"], &currentSeats)
+	if err != nil {
+		return "", fmt.Errorf("error parsing draft seats: %w", err)
+	}
+	for position, seat := range currentSeats {
+		var previousPacks, currentPacks [3]json.RawMessage
+		if position < len(previousSeats) {
+			err = json.Unmarshal(previousSeats[position]["packs"], &previousPacks)
+			if err != nil {
+				return "", fmt.Errorf("error parsing previous packs for seat %d: %w", position, err)
+			}
+		}
+		err = json.Unmarshal(seat["packs"], &currentPacks)
+		if err != nil {
+			return "", fmt.Errorf("error parsing packs for seat %d: %w", position, err)
+		}
+		for round := range currentPacks {
+			if !changedSlots[packSlot{position: position, round: round}] &&
+				bytes.Equal(previousPacks[round], currentPacks[round]) {
+				currentPacks[round] = json.RawMessage("null")
+			}
+		}
+		seat["packs"], err = json.Marshal(currentPacks)
+		if err != nil {
+			return "", fmt.Errorf("error marshalling packs for seat %d: %w", position, err)
+		}
+	}
+
+	current["events"], err = json.Marshal(newEvents)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling draft events: %w", err)
+	}
+	current["seats"], err = json.Marshal(currentSeats)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling draft seats: %w", err)
+	}
+	current["since"] = json.RawMessage(strconv.FormatInt(since, 10))
+	ret, err := json.Marshal(current)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling draft json: %w", err)
+	}
+	return string(ret), nil
+}
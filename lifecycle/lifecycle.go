@@ -0,0 +1,90 @@
+// Package lifecycle describes the states a draft moves through, from the moment
+// it is opened for joining until it is archived.
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// State is a persisted draft lifecycle state.
+type State string
+
+const (
+	// Scheduled drafts have been created ahead of time and will open for joining later.
+	Scheduled State = "scheduled"
+	// Open drafts are accepting players but no picks have been made yet.
+	Open State = "open"
+	// Drafting drafts are in progress; picks may be made.
+	Drafting State = "drafting"
+	// Deckbuilding drafts have had every pick made but no matches have been paired yet.
+	Deckbuilding State = "deckbuilding"
+	// Playing drafts have posted pairings and are waiting for match results.
+	Playing State = "playing"
+	// Complete drafts have had every match result reported.
+	Complete State = "complete"
+	// Archived drafts are hidden from the draft list.
+	Archived State = "archived"
+)
+
+// ErrInvalidTransition is returned when a draft is asked to move between two states
+// that aren't connected.
+var ErrInvalidTransition = errors.New("invalid draft state transition")
+
+// transitions lists which states each state may move to.
+// Any draft may be archived early, since drafts get abandoned and in-person drafts and
+// leagues never report every match result.
+var transitions = map[State][]State{
+	Scheduled:    {Open, Archived},
+	Open:         {Drafting, Archived},
+	Drafting:     {Deckbuilding, Archived},
+	Deckbuilding: {Playing, Archived},
+	Playing:      {Complete, Archived},
+	Complete:     {Archived},
+}
+
+// Transition describes a draft moving from one state to another.
+type Transition struct {
+	DraftID uint64
+	From    State
+	To      State
+}
+
+// Validate returns ErrInvalidTransition if from can't move directly to to.
+func Validate(from State, to State) error {
+	if !slices.Contains(transitions[from], to) {
+		return fmt.Errorf("%w: %q to %q", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// Valid reports whether s is a known state.
+func (s State) Valid() bool {
+	switch s {
+	case Scheduled, Open, Drafting, Deckbuilding, Playing, Complete, Archived:
+		return true
+	}
+	return false
+}
+
+// Joinable reports whether players may take seats in a draft in state s.
+func (s State) Joinable() bool {
+	return s == Open || s == Drafting
+}
+
+// Pickable reports whether picks may be made in a draft in state s.
+// The first pick in an open draft moves it to drafting.
+func (s State) Pickable() bool {
+	return s == Open || s == Drafting
+}
+
+// Finished reports whether every pick has been made in a draft in state s.
+func (s State) Finished() bool {
+	return s == Deckbuilding || s == Playing || s == Complete || s == Archived
+}
+
+// Concluded reports whether no more matches will be played in a draft in state s.
+func (s State) Concluded() bool {
+	return s == Complete || s == Archived
+}
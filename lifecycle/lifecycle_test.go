@@ -0,0 +1,40 @@
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateFollowsLifecycle(t *testing.T) {
+	order := []State{Scheduled, Open, Drafting, Deckbuilding, Playing, Complete, Archived}
+	for i := 0; i < len(order)-1; i++ {
+		err := Validate(order[i], order[i+1])
+		if err != nil {
+			t.Errorf("expected %s -> %s to be valid: %s", order[i], order[i+1], err.Error())
+		}
+	}
+}
+
+func TestValidateRejectsSkippingAhead(t *testing.T) {
+	for _, tc := range []struct{ from, to State }{
+		{Scheduled, Drafting},
+		{Open, Deckbuilding},
+		{Drafting, Playing},
+		{Complete, Playing},
+		{Archived, Open},
+	} {
+		err := Validate(tc.from, tc.to)
+		if !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("expected %s -> %s to be invalid, got %v", tc.from, tc.to, err)
+		}
+	}
+}
+
+func TestValidateAllowsArchivingEarly(t *testing.T) {
+	for _, from := range []State{Scheduled, Open, Drafting, Deckbuilding, Playing} {
+		err := Validate(from, Archived)
+		if err != nil {
+			t.Errorf("expected %s -> archived to be valid: %s", from, err.Error())
+		}
+	}
+}
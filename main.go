@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -23,9 +24,12 @@ import (
 
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/walkingeyerobot/r38/draftconfig"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/migrations"
 	"github.com/walkingeyerobot/r38/schema"
 
 	"github.com/walkingeyerobot/r38/makedraft"
+	"github.com/walkingeyerobot/r38/pairings"
 
 	"golang.org/x/net/xsrftoken"
 
@@ -92,6 +96,12 @@ func main() {
 	}
 	defer ob.Close()
 
+	err = migrations.RunObjectBoxMigrations(ob)
+	if err != nil {
+		log.Printf("error migrating db: %s", err.Error())
+		return
+	}
+
 	port, valid := os.LookupEnv("R38_PORT")
 	if !valid {
 		port = "12264"
@@ -168,6 +178,14 @@ func main() {
 	if err != nil {
 		log.Printf("error setting up spectator channel archive task: %s", err.Error())
 	}
+	_, err = scheduler.Every(1).Minute().Do(ProcessScheduledDrafts, ob)
+	if err != nil {
+		log.Printf("error setting up scheduled draft task: %s", err.Error())
+	}
+	_, err = scheduler.Every(1).Minute().Do(ExpireWaitlistReservations, ob)
+	if err != nil {
+		log.Printf("error setting up waitlist expiry task: %s", err.Error())
+	}
 
 	scheduler.StartAsync()
 
@@ -267,11 +285,12 @@ func NewHandler(ob *objectbox.ObjectBox, useAuth bool) http.Handler {
 			if readonly {
 				err = ob.RunInReadTx(handle)
 			} else {
-				err = ob.RunInWriteTx(handle)
+				err = runInWriteTx(ob, handle)
 			}
 			if err != nil {
 				if isApiRoute {
-					if errors.Is(err, ZoneDraftError) {
+					if errors.Is(err, ZoneDraftError) || errors.Is(err, DraftStateError) || errors.Is(err, DeckError) ||
+						errors.Is(err, ResultError) {
 						w.WriteHeader(http.StatusBadRequest)
 					} else if errors.Is(err, MethodNotAllowedError) {
 						w.WriteHeader(http.StatusMethodNotAllowed)
@@ -300,11 +319,31 @@ func NewHandler(ob *objectbox.ObjectBox, useAuth bool) http.Handler {
 	addHandler("/api/archive/", ServeAPIArchive, false)
 	addHandler("/api/draft/", ServeAPIDraft, true)
 	addHandler("/api/draftlist/", ServeAPIDraftList, true)
+	addHandler("/api/drafttiming/", ServeAPIDraftTiming, true)
+	addHandler("/api/draftprogress/", ServeAPIDraftProgress, true)
 	addHandler("/api/draftpacks/", ServeAPIDraftPacks, true)
 	addHandler("/api/pick/", ServeAPIPick, false)
 	addHandler("/api/pickrfid/", ServeAPIPickRfid, false)
 	addHandler("/api/join/", ServeAPIJoin, false)
 	addHandler("/api/skip/", ServeAPISkip, false)
+	addHandler("/api/leave/", ServeAPILeave, false)
+	addHandler("/api/waitlist/", ServeAPIWaitlist, false)
+	addHandler("/api/start/", ServeAPIStart, false)
+	addHandler("/api/deck/", ServeAPIDeck, false)
+	addHandler("/api/headtohead/", ServeAPIHeadToHead, true)
+	addHandler("/api/export/", ServeAPIExport, true)
+	addHandler("/api/export/dek/", ServeAPIExportDek, true)
+	addHandler("/api/export/dekzip/", ServeAPIExportDekZip, true)
+	addHandler("/api/export/picks/", ServeAPIExportPicks, true)
+	addHandler("/api/export/mtgolog/", ServeAPIExportMtgoLog, true)
+	addHandler("/api/import/mtgo/", ServeAPIImportMtgo, false)
+	addHandler("/api/cardstats/", ServeAPICardStats, true)
+	addHandler("/api/standings/", ServeAPIStandings, true)
+	addHandler("/api/result/", ServeAPIResult, false)
+	addHandler("/api/seasons/", ServeAPISeasons, true)
+	addHandler("/api/addseason/", ServeAPIAddSeason, false)
+	addHandler("/api/leaderboard/", ServeAPILeaderboard, true)
+	addHandler("/api/playerhistory/", ServeAPIPlayerHistory, true)
 	addHandler("/api/prefs/", ServeAPIPrefs, true)
 	addHandler("/api/setpref/", ServeAPISetPref, false)
 	addHandler("/api/undopick/", ServeAPIUndoPick, false)
@@ -318,6 +357,8 @@ func NewHandler(ob *objectbox.ObjectBox, useAuth bool) http.Handler {
 	addHandler("/api/dev/forceEnd/", ServeAPIForceEnd, false)
 	addHandler("/api/dev/toggleInPerson/", ServeAPIToggleInPerson, false)
 
+	mux.Handle("/api/draftupdates/", ServeDraftUpdates(ob))
+
 	mux.Handle("/", http.HandlerFunc(HandleIndex))
 
 	return mux
@@ -350,8 +391,7 @@ func ServeAPIArchive(_ http.ResponseWriter, r *http.Request, userID int64, ob *o
 		if err != nil {
 			return fmt.Errorf("couldn't find draft to archive: %w", err)
 		}
-		draft.Archived = true
-		_, err = box.Put(draft)
+		err = transitionDraft(ob, draft, lifecycle.Archived)
 	}
 
 	return err
@@ -381,15 +421,20 @@ func ServeAPIMakeDraft(_ http.ResponseWriter, r *http.Request, userID int64, ob
 	}
 	flagVal := false
 	settings := makedraft.Settings{
-		Name:        &postedSettings.Name,
-		Set:         &postedSettings.Set,
-		InPerson:    &postedSettings.InPerson,
-		AssignPacks: &postedSettings.AssignPacks,
-		AssignSeats: &postedSettings.AssignSeats,
-		PickTwo:     &postedSettings.PickTwo,
-		Seed:        &postedSettings.Seed,
-		Verbose:     &flagVal,
-		Simulate:    &flagVal,
+		Name:                &postedSettings.Name,
+		Set:                 &postedSettings.Set,
+		InPerson:            &postedSettings.InPerson,
+		AssignPacks:         &postedSettings.AssignPacks,
+		AssignSeats:         &postedSettings.AssignSeats,
+		PickTwo:             &postedSettings.PickTwo,
+		Seed:                &postedSettings.Seed,
+		Verbose:             &flagVal,
+		Simulate:            &flagVal,
+		OpenAt:              &postedSettings.OpenAt,
+		StartAt:             &postedSettings.StartAt,
+		Rounds:              &postedSettings.Rounds,
+		SpectatorDelayPicks: &postedSettings.SpectatorDelayPicks,
+		SpectatorDelay:      &postedSettings.SpectatorDelay,
 	}
 
 	return makedraft.MakeDraft(settings, ob)
@@ -407,9 +452,21 @@ func ServeAPIDraft(w http.ResponseWriter, r *http.Request, userID int64, ob *obj
 		return fmt.Errorf("bad api url: %w", err)
 	}
 
-	draftJSON, err := GetFilteredJSON(ob, draftID, userID)
-	if err != nil {
-		return fmt.Errorf("error getting json: %w", err)
+	var draftJSON string
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		since, err := strconv.ParseInt(sinceParam, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad since value: %w", err)
+		}
+		draftJSON, err = GetIncrementalJSON(ob, draftID, userID, since)
+		if err != nil {
+			return fmt.Errorf("error getting json: %w", err)
+		}
+	} else {
+		draftJSON, err = GetFilteredJSON(ob, draftID, userID)
+		if err != nil {
+			return fmt.Errorf("error getting json: %w", err)
+		}
 	}
 
 	_, err = fmt.Fprint(w, draftJSON)
@@ -482,8 +539,8 @@ func ServeAPIDraftPacks(w http.ResponseWriter, r *http.Request, userId int64, ob
 }
 
 // ServeAPIPrefs serves the /api/prefs endpoint.
-func ServeAPIPrefs(w http.ResponseWriter, _ *http.Request, userId int64, _ *objectbox.ObjectBox) error {
-	prefs, err := GetUserPrefs(userId)
+func ServeAPIPrefs(w http.ResponseWriter, _ *http.Request, userId int64, ob *objectbox.ObjectBox) error {
+	prefs, err := GetUserPrefs(ob, userId)
 	if err != nil {
 		return err
 	}
@@ -521,73 +578,47 @@ func ServeAPISetPref(w http.ResponseWriter, r *http.Request, userId int64, ob *o
 		}
 	}
 
+	if pref.FormatPref.Format != "" {
+		err = setFormatPref(ob, userId, pref.FormatPref)
+		if err != nil {
+			return fmt.Errorf("error updating format preference: %w", err)
+		}
+	}
+
 	return ServeAPIPrefs(w, r, userId, ob)
 }
 
-func ServeAPIUserStats(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
-	userStats := UserStats{}
-
-	user, err := schema.BoxForUser(ob).Get(uint64(userID))
-	if err != nil || user == nil {
-		return err
+// setFormatPref records whether a user wants to be assigned seats in drafts of a format.
+func setFormatPref(ob *objectbox.ObjectBox, userId int64, pref UserFormatPref) error {
+	if !slices.Contains(formats(), pref.Format) {
+		return fmt.Errorf("unknown format %q", pref.Format)
 	}
 
-	drafts, err := schema.BoxForDraft(ob).Query(
-		objectbox.Any(schema.Draft_.Archived.Equals(false),
-			schema.Draft_.Archived.IsNil(),
-		),
-		schema.Draft_.Seats.Link(schema.Seat_.User.Equals(uint64(userID))),
-	).Find()
+	userBox := schema.BoxForUser(ob)
+	user, err := userBox.Get(uint64(userId))
 	if err != nil {
 		return err
 	}
-
-	draftedColors := []int{0, 0, 0, 0, 0, 0}
-
-	for _, draft := range drafts {
-		entry := draftToDraftListEntry(draft, user)
-		if entry.Finished {
-			userStats.CompletedDrafts++
-		} else {
-			userStats.ActiveDrafts++
-		}
-
-		seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-			return seat.User.Id == uint64(userID)
-		})
-		if seatIndex != -1 {
-			seat := draft.Seats[seatIndex]
-			for _, card := range seat.PickedCards {
-				var cardData R38CardData
-				err = json.Unmarshal([]byte(card.Data), &cardData)
-				if err != nil {
-					continue
-				}
-				colors := cardData.Scryfall.Colors
-				for _, color := range colors {
-					switch {
-					case color == "W":
-						draftedColors[0]++
-					case color == "U":
-						draftedColors[1]++
-					case color == "B":
-						draftedColors[2]++
-					case color == "R":
-						draftedColors[3]++
-					case color == "G":
-						draftedColors[4]++
-					}
-				}
-				if len(colors) == 0 {
-					draftedColors[5]++
-				}
-			}
-		}
+	if user == nil {
+		return fmt.Errorf("no user %d", userId)
 	}
 
-	userStats.DraftedColors = draftedColors
+	prefIndex := slices.IndexFunc(user.FormatPrefs, func(formatPref *schema.FormatPref) bool {
+		return formatPref.Format == pref.Format
+	})
+	if prefIndex != -1 {
+		formatPref := user.FormatPrefs[prefIndex]
+		formatPref.Elig = pref.Elig
+		_, err = schema.BoxForFormatPref(ob).Put(formatPref)
+		return err
+	}
 
-	return json.NewEncoder(w).Encode(userStats)
+	user.FormatPrefs = append(user.FormatPrefs, &schema.FormatPref{
+		Format: pref.Format,
+		Elig:   pref.Elig,
+	})
+	_, err = userBox.Put(user)
+	return err
 }
 
 // ServeAPIPick serves the /api/pick endpoint.
@@ -634,6 +665,10 @@ func ServeAPIPickRfid(w http.ResponseWriter, r *http.Request, userId int64, ob *
 	if err != nil {
 		return fmt.Errorf("error finding card in active draft: %w", err)
 	}
+	err = checkDraftState(draft, "pick", lifecycle.State.Pickable)
+	if err != nil {
+		return err
+	}
 	var seatIndex = slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
 		return seat.User != nil && seat.User.Id == uint64(userId)
 	})
@@ -714,7 +749,7 @@ func doHandlePostedPick(w http.ResponseWriter, pick PostedPick, userId int64, ob
 			// We can't send the actual error back to the client without leaking information about
 			// where the card they tried to pick actually is.
 			log.Printf("error making pick: %s", err.Error())
-			if errors.Is(err, ZoneDraftError) {
+			if errors.Is(err, ZoneDraftError) || errors.Is(err, DraftStateError) {
 				return fmt.Errorf("error making pick: %w", err)
 			} else {
 				return fmt.Errorf("error making pick")
@@ -757,6 +792,10 @@ func ServeAPIUndoPick(_ http.ResponseWriter, r *http.Request, userID int64, ob *
 	if err != nil {
 		return err
 	}
+	err = checkDraftState(draft, "undo a pick", lifecycle.State.Pickable)
+	if err != nil {
+		return err
+	}
 	seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
 		return seat.User != nil && seat.User.Id == uint64(userID)
 	})
@@ -774,6 +813,10 @@ func ServeAPIUndoPick(_ http.ResponseWriter, r *http.Request, userID int64, ob *
 		}
 	}
 
+	// Undoing counts as a change of its own, so clients can tell their copy of the draft is out of date.
+	draft.Modified = nextEventModifiedValue(draft)
+	draft.UndoneModified = draft.Modified
+	draft.UndonePickModified = lastEvent.Modified
 	draft.Events = slices.DeleteFunc(draft.Events, func(e *schema.Event) bool {
 		return e == lastEvent
 	})
@@ -814,7 +857,11 @@ func ServeAPIUndoPick(_ http.ResponseWriter, r *http.Request, userID int64, ob *
 	})
 	seat.Packs = append(seat.Packs, pack)
 	_, err = seatBox.Put(seat)
-	return err
+	if err != nil {
+		return err
+	}
+	queueDraftUpdate(draft, DraftUpdateUndo)
+	return nil
 }
 
 // ServeAPIJoin serves the /api/join endpoint.
@@ -864,6 +911,10 @@ func doJoin(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
 	if err != nil {
 		return err
 	}
+	err = checkDraftState(draft, "join", lifecycle.State.Joinable)
+	if err != nil {
+		return err
+	}
 
 	var reservedSeat *schema.Seat
 	var openSeats []*schema.Seat
@@ -873,7 +924,7 @@ func doJoin(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
 		}
 		if seat.ReservedUser != nil && seat.ReservedUser.Id == uint64(userId) {
 			reservedSeat = seat
-		} else if seat.User == nil {
+		} else if seat.User == nil && seat.ReservedUser == nil {
 			openSeats = append(openSeats, seat)
 		}
 	}
@@ -895,6 +946,10 @@ func doJoinSeatPosition(ob *objectbox.ObjectBox, userId int64, draftId int64, po
 	if err != nil {
 		return err
 	}
+	err = checkDraftState(draft, "join", lifecycle.State.Joinable)
+	if err != nil {
+		return err
+	}
 
 	if slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
 		return seat.User != nil && seat.User.Id == uint64(userId)
@@ -924,11 +979,18 @@ func doJoinSeat(ob *objectbox.ObjectBox, userId int64, draft *schema.Draft, seat
 	}
 
 	seat.User = user
+	seat.ReservedUntil = time.Time{}
 	_, err = schema.BoxForSeat(ob).Put(seat)
 	if err != nil {
 		return err
 	}
 
+	_, err = removeFromWaitlist(ob, draft, userId)
+	if err != nil {
+		return err
+	}
+	queueDraftUpdate(draft, DraftUpdateJoin)
+
 	if dg != nil && draft.SpectatorChannelId != "" && user.DiscordId != "" {
 		err = dg.ChannelPermissionSet(draft.SpectatorChannelId, user.DiscordId, 1, 0, discordgo.PermissionViewChannel)
 		if err != nil {
@@ -942,6 +1004,18 @@ func doJoinSeat(ob *objectbox.ObjectBox, userId int64, draft *schema.Draft, seat
 		})
 	}
 
+	full := !slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
+		return seat.User == nil
+	})
+	// Drafts scheduled to start later wait for their start time even once they're full.
+	waitingForStart := isTimeSet(draft.StartAt) && draft.StartAt.After(time.Now())
+	if full && !waitingForStart && lifecycle.State(draft.State) == lifecycle.Open {
+		err = transitionDraft(ob, draft, lifecycle.Drafting)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -985,19 +1059,44 @@ func doSkip(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
 	}
 
 	if slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-		return seat.User.Id == uint64(userId)
+		return seat.User != nil && seat.User.Id == uint64(userId)
 	}) != -1 {
 		return fmt.Errorf("user %d already joined %d", userId, draftId)
 	}
 
 	reservedSeatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-		return seat.ReservedUser.Id == uint64(userId)
+		return seat.ReservedUser != nil && seat.ReservedUser.Id == uint64(userId)
 	})
 	if reservedSeatIndex == -1 {
 		return fmt.Errorf("no seat reserved for user %d in draft %d", userId, draftId)
 	}
 	seat := draft.Seats[reservedSeatIndex]
 
+	err = recordSkip(ob, userId, draftId)
+	if err != nil {
+		return err
+	}
+
+	err = promoteFromWaitlist(ob, draft, seat)
+	if err != nil || seat.ReservedUser != nil {
+		return err
+	}
+
+	// Nobody is waiting for a seat, so offer it to the next user who opted into the format.
+	userIds, err := makedraft.AssignSeats(ob, draftId, draft.Format, 1)
+	if err != nil || len(userIds) == 0 {
+		return err
+	}
+	seat.ReservedUser, err = schema.BoxForUser(ob).Get(uint64(userIds[0]))
+	if err != nil {
+		return err
+	}
+	_, err = schema.BoxForSeat(ob).Put(seat)
+	return err
+}
+
+// recordSkip remembers that a user turned down a seat in a draft.
+func recordSkip(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
 	userBox := schema.BoxForUser(ob)
 	user, err := userBox.Get(uint64(userId))
 	if err != nil {
@@ -1007,29 +1106,29 @@ func doSkip(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
 		DraftId: uint64(draftId),
 	})
 	_, err = userBox.Put(user)
-	if err != nil {
-		return err
+	return err
+}
+
+// ServeAPIStart serves the /api/start endpoint.
+func ServeAPIStart(_ http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if r.Method != "POST" {
+		return MethodNotAllowedError
+	}
+	if userID != 1 {
+		return fmt.Errorf("not allowed")
 	}
 
-	newUser, err := makedraft.AssignSeats(ob, draftId, 1)
-	if err != nil {
-		return err
+	re := regexp.MustCompile(`/api/start/(\d+)`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
 	}
-	if len(newUser) > 0 {
-		seat.ReservedUser = nil
-	} else {
-		reservedUser, err := userBox.Get(uint64(newUser[0]))
-		if err != nil {
-			return err
-		}
-		seat.ReservedUser = reservedUser
-		_, err = schema.BoxForSeat(ob).Put(seat)
-		if err != nil {
-			return err
-		}
+	draftID, err := strconv.ParseInt(parseResult[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad api url: %w", err)
 	}
 
-	return nil
+	return startDraft(ob, draftID)
 }
 
 // ServeAPIForceEnd serves the /api/dev/forceEnd testing endpoint.
@@ -1046,7 +1145,11 @@ func ServeAPIForceEnd(_ http.ResponseWriter, r *http.Request, userID int64, ob *
 		}
 
 		draftID := toJoin.ID
-		return NotifyEndOfDraft(ob, draftID)
+		draft, err := schema.BoxForDraft(ob).Get(uint64(draftID))
+		if err != nil {
+			return fmt.Errorf("error loading draft %d: %w", draftID, err)
+		}
+		return transitionDraft(ob, draft, lifecycle.Deckbuilding)
 	} else {
 		return http.ErrBodyNotAllowed
 	}
@@ -1231,6 +1334,15 @@ func ServeAPISet(w http.ResponseWriter, r *http.Request, _ int64, _ *objectbox.O
 	return nil
 }
 
+func makeUserInfo(user *schema.User) UserInfo {
+	return UserInfo{
+		ID:       int64(user.Id),
+		Name:     user.DiscordName,
+		Picture:  user.Picture,
+		MtgoName: user.MtgoName,
+	}
+}
+
 func getUserJSON(userId int64, ob *objectbox.ObjectBox) ([]byte, error) {
 	var userInfo UserInfo
 
@@ -1239,10 +1351,7 @@ func getUserJSON(userId int64, ob *objectbox.ObjectBox) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		userInfo.ID = int64(user.Id)
-		userInfo.Name = user.DiscordName
-		userInfo.Picture = user.Picture
-		userInfo.MtgoName = user.MtgoName
+		userInfo = makeUserInfo(user)
 	}
 
 	userInfoJSON, err := json.Marshal(userInfo)
@@ -1276,6 +1385,26 @@ func doPick(ob *objectbox.ObjectBox, userId int64, draftId int64, cardId int64)
 	if err != nil {
 		return myPackID, announcements, round, nil, err
 	}
+	err = checkDraftState(draft, "pick", lifecycle.State.Pickable)
+	if err != nil {
+		return myPackID, announcements, round, nil, err
+	}
+	// Online drafts wait for the pod to fill or an admin to start them. In-person drafts start
+	// with their first pick.
+	if !draft.InPerson {
+		err = checkDraftState(draft, "pick", func(state lifecycle.State) bool {
+			return state == lifecycle.Drafting
+		})
+		if err != nil {
+			return myPackID, announcements, round, nil, err
+		}
+	}
+	if lifecycle.State(draft.State) == lifecycle.Open {
+		err = transitionDraft(ob, draft, lifecycle.Drafting)
+		if err != nil {
+			return myPackID, announcements, round, nil, err
+		}
+	}
 
 	numSeats, cardsPerPack := getNumSeatsAndCardsPerPack(draft)
 
@@ -1416,26 +1545,20 @@ func doPick(ob *objectbox.ObjectBox, userId int64, draftId int64, cardId int64)
 					}
 				}
 				if nextRoundPlayers == numSeats && len(seat.PickedCards) == cardsPerPack*3 {
-					// The draft is over. Notify the admin.
-					err = NotifyEndOfDraft(ob, draftId)
+					// The draft is over. Moving it along its lifecycle notifies the admin.
+					err = transitionDraft(ob, draft, lifecycle.Deckbuilding)
 					if err != nil {
-						log.Printf("error notifying end of draft: %s", err.Error())
+						return myPackID, announcements, round, seat, err
 					}
 				} else if nextRoundPlayers > 1 && !draft.InPerson {
 					// Now we know that we are not the only player in this round.
-					blockingDiscordId := ""
-					for _, s := range draft.Seats {
-						if len(s.Packs) > 0 {
-							if blockingDiscordId != "" || s.User == nil {
-								blockingDiscordId = ""
-								break
-							}
-							blockingDiscordId = s.User.DiscordId
+					blocking := findBlockingSeat(draft)
+					if blocking != nil && blocking.User.DiscordId != "" {
+						err = NotifyByDraftAndDiscordID(draftId, blocking.User.DiscordId)
+						if err != nil {
+							log.Printf("error notifying blocking player in draft %d", draftId)
 						}
 					}
-					if blockingDiscordId != "" {
-						err = NotifyByDraftAndDiscordID(draftId, blockingDiscordId)
-					}
 				}
 			}
 		}
@@ -1513,34 +1636,11 @@ func NotifyEndOfDraft(ob *objectbox.ObjectBox, draftID int64) error {
 }
 
 func PostFirstRoundPairings(ob *objectbox.ObjectBox, draft *schema.Draft) error {
-	drafterIds := [8]string{}
-	for _, seat := range draft.Seats {
-		if len(seat.User.DiscordId) > 0 {
-			drafterIds[seat.Position] = fmt.Sprintf("<@%s>", seat.User.DiscordId)
-		} else {
-			drafterIds[seat.Position] = seat.User.DiscordName
-		}
-	}
-
-	var pairings string
-	if len(draft.Seats) == 8 {
-		pairings = fmt.Sprintf(`%s vs %s
-%s vs %s
-%s vs %s
-%s vs %s`,
-			drafterIds[0], drafterIds[4],
-			drafterIds[1], drafterIds[5],
-			drafterIds[2], drafterIds[6],
-			drafterIds[3], drafterIds[7])
-	} else {
-		pairings = fmt.Sprintf(`%s vs %s
-%s vs %s`,
-			drafterIds[0], drafterIds[2],
-			drafterIds[1], drafterIds[3])
+	err := transitionDraft(ob, draft, lifecycle.Playing)
+	if err != nil {
+		return err
 	}
-	round := 1
-	err := PostPairings(ob, draft, round, pairings)
-	return err
+	return pairNextRound(ob, draft)
 }
 
 func PostPairings(ob *objectbox.ObjectBox, draft *schema.Draft, round int, pairings string) error {
@@ -1551,7 +1651,7 @@ func PostPairings(ob *objectbox.ObjectBox, draft *schema.Draft, round int, pairi
 			Description: pairings,
 			Color:       Pink,
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "React with 🏆 if you win and 💀 if you lose.",
+				Text: resultReactionsHelp(),
 			},
 		})
 	if err != nil {
@@ -1561,13 +1661,11 @@ func PostPairings(ob *objectbox.ObjectBox, draft *schema.Draft, round int, pairi
 	if msg == nil {
 		return nil
 	}
-	err = dg.MessageReactionAdd(msg.ChannelID, msg.ID, "🏆")
-	if err != nil {
-		log.Printf("%s", err.Error())
-	}
-	err = dg.MessageReactionAdd(msg.ChannelID, msg.ID, "💀")
-	if err != nil {
-		log.Printf("%s", err.Error())
+	for _, reaction := range resultReactions {
+		err = dg.MessageReactionAdd(msg.ChannelID, msg.ID, reaction.emoji)
+		if err != nil {
+			log.Printf("%s", err.Error())
+		}
 	}
 	_, err = schema.BoxForPairingMsg(ob).Put(&schema.PairingMsg{
 		MsgId: msg.ID,
@@ -1656,6 +1754,7 @@ func GetJSONObject(ob *objectbox.ObjectBox, draftId int64) (DraftJSON, error) {
 	draftJson.DraftName = draft.Name
 	draftJson.InPerson = draft.InPerson
 	draftJson.PickTwo = draft.PickTwo
+	draftJson.Modified = int64(draftModified(draft))
 
 	for _, seat := range draft.Seats {
 		draftJson.Seats = append(draftJson.Seats, Seat{})
@@ -1667,6 +1766,17 @@ func GetJSONObject(ob *objectbox.ObjectBox, draftId int64) (DraftJSON, error) {
 		}
 		draftJson.Seats[seat.Position].ScanSound = int64(seat.ScanSound)
 		draftJson.Seats[seat.Position].ErrorSound = int64(seat.ErrorSound)
+		// Decks stay secret from opponents until every match has been played.
+		if lifecycle.State(draft.State).Concluded() {
+			deck, err := getDeck(ob, seat)
+			if err != nil {
+				return draftJson, err
+			}
+			if deck != nil && deck.Locked {
+				deckJson := deckToJSON(seat, deck)
+				draftJson.Seats[seat.Position].Deck = &deckJson
+			}
+		}
 		for _, pack := range seat.OriginalPacks {
 			for _, card := range pack.OriginalCards {
 				dataObj := make(map[string]interface{})
@@ -1696,6 +1806,9 @@ func GetJSONObject(ob *objectbox.ObjectBox, draftId int64) (DraftJSON, error) {
 		}
 		eventJson.Type = "Pick"
 		eventJson.DraftModified = int64(event.Modified)
+		if isTimeSet(event.Timestamp) {
+			eventJson.Timestamp = &event.Timestamp
+		}
 		draftJson.Events = append(draftJson.Events, eventJson)
 	}
 
@@ -1704,23 +1817,49 @@ func GetJSONObject(ob *objectbox.ObjectBox, draftId int64) (DraftJSON, error) {
 
 // GetFilteredJSON returns a filtered json object of replay data.
 func GetFilteredJSON(ob *objectbox.ObjectBox, draftId int64, userId int64) (string, error) {
-	draftInfo, err := GetDraftListEntry(userId, ob, draftId)
+	draft, access, err := getReplay(ob, draftId, userId, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return filterDraftJSON(draft, userId, access != filteredReplay)
+}
+
+// getReplay returns a draft's replay data and how much of it the user may see. Spectators' replay
+// data is already delayed, but anyone else's still needs to go through filterDraftJSON.
+func getReplay(ob *objectbox.ObjectBox, draftId int64, userId int64, now time.Time) (DraftJSON, replayAccess, error) {
+	access, err := getReplayAccess(ob, draftId, userId)
 	if err != nil {
-		return "", fmt.Errorf("error getting draft list entry: %w", err)
+		return DraftJSON{}, access, err
 	}
 
-	var draft DraftJSON
-	draft, err = GetJSONObject(ob, draftId)
+	draft, err := GetJSONObject(ob, draftId)
 	if err != nil {
-		return "", fmt.Errorf("error getting draft details: %w", err)
+		return draft, access, fmt.Errorf("error getting draft details: %w", err)
 	}
 
 	draft.PickXsrf = xsrftoken.Generate(xsrfKey, strconv.FormatInt(userId, 16), fmt.Sprintf("pick%d", draftId))
 
-	var returnFullReplay bool
+	if access == delayedReplay {
+		storedDraft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+		if err != nil {
+			return draft, access, fmt.Errorf("error getting draft %d: %w", draftId, err)
+		}
+		delayForSpectators(&draft, storedDraft, now)
+	}
+	return draft, access, nil
+}
+
+// getReplayAccess works out how much of a draft's replay a user may see.
+func getReplayAccess(ob *objectbox.ObjectBox, draftId int64, userId int64) (replayAccess, error) {
+	draftInfo, err := GetDraftListEntry(userId, ob, draftId)
+	if err != nil {
+		return filteredReplay, fmt.Errorf("error getting draft list entry: %w", err)
+	}
+
+	access := filteredReplay
 	if draftInfo.Finished {
 		// If the draft is over, everyone can see the full replay.
-		returnFullReplay = true
+		access = fullReplay
 	} else if draftInfo.Joined {
 		// If we're a member of the draft and it's NOT over,
 		// we need to see if we're done with the draft. If we are,
@@ -1728,20 +1867,25 @@ func GetFilteredJSON(ob *objectbox.ObjectBox, draftId int64, userId int64) (stri
 		// filter.
 		draft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
 		if err != nil {
-			return "", fmt.Errorf("error detecting end of draft %d for user %d: %w", draftId, userId, err)
+			return filteredReplay, fmt.Errorf("error detecting end of draft %d for user %d: %w", draftId, userId, err)
 		}
 		for _, seat := range draft.Seats {
-			if seat.User != nil && seat.User.Id == uint64(userId) {
-				returnFullReplay = seat.Round >= 4
+			if seat.User != nil && seat.User.Id == uint64(userId) && seat.Round >= 4 {
+				access = fullReplay
 				break
 			}
 		}
 	} else if userId != 0 && draftInfo.AvailableSeats == 0 && draftInfo.ReservedSeats == 0 {
 		// If we're logged in AND the draft is full,
-		// we can see the full replay.
-		returnFullReplay = true
+		// we can spectate, a safe distance behind the drafters.
+		access = delayedReplay
 	}
+	return access, nil
+}
 
+// filterDraftJSON hides what a user shouldn't see in a draft's replay data, unless they can see the
+// full replay, and returns it as json.
+func filterDraftJSON(draft DraftJSON, userId int64, returnFullReplay bool) (string, error) {
 	if returnFullReplay {
 		ret, err := json.Marshal(draft)
 		if err != nil {
@@ -1803,6 +1947,7 @@ func doEvent(ob *objectbox.ObjectBox, draftId int64, announcements []string, car
 		return err
 	}
 	pack, err := schema.BoxForPack(ob).Get(uint64(packId))
+	draft.Modified = nextEventModifiedValue(draft)
 	if cardId2 != nil {
 		card2, err := schema.BoxForCard(ob).Get(uint64(*cardId2))
 		if err != nil {
@@ -1814,8 +1959,9 @@ func doEvent(ob *objectbox.ObjectBox, draftId int64, announcements []string, car
 			Card1:        card1,
 			Card2:        card2,
 			Pack:         pack,
-			Modified:     nextEventModifiedValue(draft),
+			Modified:     draft.Modified,
 			Round:        int(round),
+			Timestamp:    time.Now(),
 		})
 	} else {
 		draft.Events = append(draft.Events, &schema.Event{
@@ -1824,22 +1970,31 @@ func doEvent(ob *objectbox.ObjectBox, draftId int64, announcements []string, car
 			Card1:        card1,
 			Card2:        nil,
 			Pack:         pack,
-			Modified:     nextEventModifiedValue(draft),
+			Modified:     draft.Modified,
 			Round:        int(round),
+			Timestamp:    time.Now(),
 		})
 	}
 
 	_, err = draftBox.Put(draft)
-	return err
+	if err != nil {
+		return err
+	}
+	queueDraftUpdate(draft, DraftUpdatePick)
+	return nil
 }
 
 func nextEventModifiedValue(draft *schema.Draft) int {
-	if len(draft.Events) == 0 {
-		return 1
+	return draftModified(draft) + 1
+}
+
+// draftModified returns the latest DraftModified value handed out in a draft.
+func draftModified(draft *schema.Draft) int {
+	modified := draft.Modified
+	for _, event := range draft.Events {
+		modified = max(modified, event.Modified)
 	}
-	return slices.MaxFunc(draft.Events, func(a, b *schema.Event) int {
-		return a.Modified - b.Modified
-	}).Modified + 1
+	return modified
 }
 
 func GetDraftList(userId int64, ob *objectbox.ObjectBox) (DraftList, error) {
@@ -1877,6 +2032,11 @@ func AddStatus(d DraftListEntry, userId int64) DraftListEntry {
 		d.Status = "spectator"
 	} else if userId == 0 {
 		d.Status = "closed"
+	} else if d.Waitlisted {
+		d.Status = "waitlisted"
+	} else if d.AvailableSeats == 0 && !d.Skipped && lifecycle.State(d.State).Joinable() {
+		// Full drafts can still be joined by waiting for someone to leave.
+		d.Status = "waitlist"
 	} else if d.AvailableSeats == 0 && d.ReservedSeats == 0 {
 		d.Status = "spectator"
 	} else if d.AvailableSeats == 0 || d.Skipped {
@@ -1911,7 +2071,6 @@ func GetDraftListEntry(userId int64, ob *objectbox.ObjectBox, draftId int64) (Dr
 func draftToDraftListEntry(draft *schema.Draft, user *schema.User) DraftListEntry {
 	numAvailable := int64(0)
 	numReserved := int64(0)
-	finished := true
 	joined := false
 	reserved := false
 
@@ -1928,34 +2087,66 @@ func draftToDraftListEntry(draft *schema.Draft, user *schema.User) DraftListEntr
 		} else {
 			numAvailable++
 		}
-		if seat.Round <= 3 {
-			finished = false
-		}
 	}
 
 	skipped := slices.ContainsFunc(user.Skips, func(skip *schema.Skip) bool {
 		return skip.DraftId == draft.Id
 	})
+	waitlisted := slices.ContainsFunc(draft.Waitlist, func(entry *schema.WaitlistEntry) bool {
+		return entry.User != nil && entry.User.Id == user.Id
+	})
 
 	return AddStatus(DraftListEntry{
 		AvailableSeats: numAvailable,
 		ReservedSeats:  numReserved,
-		Finished:       finished,
+		Finished:       lifecycle.State(draft.State).Finished(),
+		State:          draft.State,
 		ID:             int64(draft.Id),
 		Joined:         joined,
 		Reserved:       reserved,
 		Skipped:        skipped,
+		Waitlisted:     waitlisted,
+		WaitlistLength: int64(len(draft.Waitlist)),
 		Name:           draft.Name,
 		InPerson:       draft.InPerson,
 	}, int64(user.Id))
 }
 
-func GetUserPrefs(_ int64) (UserFormatPrefs, error) {
-	var prefs UserFormatPrefs
+// GetUserPrefs lists every format in sets/ along with whether the user has opted into it.
+func GetUserPrefs(ob *objectbox.ObjectBox, userId int64) (UserFormatPrefs, error) {
+	prefs := UserFormatPrefs{Prefs: []UserFormatPref{}}
+
+	user, err := schema.BoxForUser(ob).Get(uint64(userId))
+	if err != nil {
+		return prefs, err
+	}
+	if user == nil {
+		return prefs, fmt.Errorf("no user %d", userId)
+	}
+
+	for _, format := range formats() {
+		prefs.Prefs = append(prefs.Prefs, UserFormatPref{
+			Format: format,
+			Name:   format,
+			Elig: slices.ContainsFunc(user.FormatPrefs, func(pref *schema.FormatPref) bool {
+				return pref.Format == format && pref.Elig
+			}),
+		})
+	}
 
 	return prefs, nil
 }
 
+// formats returns the names of all the sets drafts can be made from.
+func formats() []string {
+	setFiles, _ := filepath.Glob("sets/*.json")
+	var names []string
+	for _, setFile := range setFiles {
+		names = append(names, strings.TrimSuffix(filepath.Base(setFile), ".json"))
+	}
+	return names
+}
+
 func DiscordReady(s *discordgo.Session, _ *discordgo.Ready) {
 	err := s.UpdateCustomStatus("Tier 5 Wolf Combo")
 	if err != nil {
@@ -1978,7 +2169,7 @@ func MakeDraftFromMessage(ob *objectbox.ObjectBox, msg string, authorID string)
 		if err != nil {
 			resp = fmt.Sprintf("%s", err.Error())
 		} else {
-			err = ob.RunInWriteTx(func() error {
+			err = runInWriteTx(ob, func() error {
 				return makedraft.MakeDraft(settings, ob)
 			})
 			if err != nil {
@@ -1988,12 +2179,36 @@ func MakeDraftFromMessage(ob *objectbox.ObjectBox, msg string, authorID string)
 			}
 		}
 		return resp, nil
+	} else if strings.HasPrefix(msg, "startdraft") {
+		if len(args) != 2 {
+			return "usage: startdraft <draft id>", nil
+		}
+		draftID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Sprintf("bad draft id %s", args[1]), nil
+		}
+		err = runInWriteTx(ob, func() error {
+			return startDraft(ob, draftID)
+		})
+		if err != nil {
+			return fmt.Sprintf("can't start draft :( %s", err.Error()), nil
+		}
+		return fmt.Sprintf("started draft %d!", draftID), nil
 	}
 	return "Unknown command", nil
 }
 
 func DiscordMsgCreate(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg *discordgo.MessageCreate) {
 	return func(s *discordgo.Session, msg *discordgo.MessageCreate) {
+		if msg.GuildID != "" && strings.HasPrefix(msg.Content, "!h2h") {
+			err := ob.RunInReadTx(func() error {
+				return AnswerHeadToHead(ob, msg.ChannelID, msg.Author.ID, msg.Content)
+			})
+			if err != nil {
+				log.Printf("Error responding to discord bot !h2h: %s", err.Error())
+			}
+			return
+		}
 		if msg.Author.ID == Boss || msg.Author.ID == Henchman {
 			if msg.GuildID == "" {
 				resp, err := MakeDraftFromMessage(ob, msg.Content, msg.Author.ID)
@@ -2010,6 +2225,13 @@ func DiscordMsgCreate(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg *d
 				DiscordSendRoleReactionMessage(s, ob, msg.ChannelID,
 					ForestBear, ForestBearId, DraftAlertsRole,
 					"Draft alerts", "if you would like notifications for games being played")
+			} else if msg.Content == "!leaderboard" {
+				err := ob.RunInReadTx(func() error {
+					return AnnounceLeaderboard(ob, msg.ChannelID)
+				})
+				if err != nil {
+					log.Printf("Error responding to discord bot !leaderboard: %s", err.Error())
+				}
 			}
 		}
 	}
@@ -2045,7 +2267,7 @@ func DiscordSendRoleReactionMessage(s *discordgo.Session, ob *objectbox.ObjectBo
 
 func DiscordReactionAdd(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg *discordgo.MessageReactionAdd) {
 	return func(s *discordgo.Session, msg *discordgo.MessageReactionAdd) {
-		err := ob.RunInWriteTx(func() error {
+		err := runInWriteTx(ob, func() error {
 			roleMsgs, err := schema.BoxForRoleMsg(ob).Query(schema.RoleMsg_.MsgId.Equals(msg.MessageID, true)).Find()
 			if err != nil {
 				log.Printf("%s", err.Error())
@@ -2076,28 +2298,26 @@ func DiscordReactionAdd(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg
 				if len(users) == 0 {
 					return fmt.Errorf("couldn't find user %s", msg.UserID)
 				}
-				if msg.Emoji.Name == "🏆" {
-					_, err = schema.BoxForResult(ob).Put(&schema.Result{
-						Draft:     pairingMsgs[0].Draft,
-						Round:     pairingMsgs[0].Round,
-						User:      users[0],
-						Win:       true,
-						Timestamp: time.Now(),
-					})
-				} else if msg.Emoji.Name == "💀" {
-					_, err = schema.BoxForResult(ob).Put(&schema.Result{
-						Draft:     pairingMsgs[0].Draft,
-						Round:     pairingMsgs[0].Round,
-						User:      users[0],
-						Win:       false,
-						Timestamp: time.Now(),
-					})
+				reaction := slices.IndexFunc(resultReactions, func(reaction resultReaction) bool {
+					return reaction.emoji == msg.Emoji.Name
+				})
+				if reaction == -1 {
+					return nil
 				}
+				draft := pairingMsgs[0].Draft
+				pairing, err := findPairing(ob, draft, pairingMsgs[0].Round, users[0])
+				if err != nil {
+					log.Printf("%s", err.Error())
+					return err
+				}
+				// Reactions are reports like any other, so the opponent reacting with the mirrored score
+				// confirms the result.
+				score := resultReactions[reaction]
+				err = reportResult(ob, draft, pairing, users[0], score.wins, score.losses, score.draws)
 				if err != nil {
 					log.Printf("%s", err.Error())
 					return err
 				}
-				CheckNextRoundPairings(ob, pairingMsgs[0].Draft, pairingMsgs[0].Round)
 			}
 			return nil
 		})
@@ -2109,7 +2329,7 @@ func DiscordReactionAdd(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg
 
 func DiscordReactionRemove(ob *objectbox.ObjectBox) func(s *discordgo.Session, msg *discordgo.MessageReactionRemove) {
 	return func(s *discordgo.Session, msg *discordgo.MessageReactionRemove) {
-		err := ob.RunInWriteTx(func() error {
+		err := runInWriteTx(ob, func() error {
 			roleMsgs, err := schema.BoxForRoleMsg(ob).Query(schema.RoleMsg_.MsgId.Equals(msg.MessageID, true)).Find()
 			if err != nil {
 				return err
@@ -2132,31 +2352,19 @@ func DiscordReactionRemove(ob *objectbox.ObjectBox) func(s *discordgo.Session, m
 				if len(users) == 0 {
 					return fmt.Errorf("couldn't find user %s", msg.UserID)
 				}
-				resultBox := schema.BoxForResult(ob)
-				results, err := resultBox.Query(schema.Result_.Draft.Equals(pairingMsgs[0].Draft.Id)).Find()
+				reaction := slices.IndexFunc(resultReactions, func(reaction resultReaction) bool {
+					return reaction.emoji == msg.Emoji.Name
+				})
+				if reaction == -1 {
+					return nil
+				}
+				draft := pairingMsgs[0].Draft
+				pairing, err := findPairing(ob, draft, pairingMsgs[0].Round, users[0])
 				if err != nil {
 					return err
 				}
-				if len(results) > 0 {
-					var win bool
-					if msg.Emoji.Name == "🏆" {
-						win = true
-					} else if msg.Emoji.Name == "💀" {
-						win = false
-					} else {
-						return nil
-					}
-					for _, result := range results {
-						if result.User.Id == users[0].Id &&
-							result.Round == pairingMsgs[0].Round &&
-							result.Win == win {
-							err = resultBox.Remove(result)
-							if err != nil {
-								return err
-							}
-						}
-					}
-				}
+				score := resultReactions[reaction]
+				return withdrawResult(ob, draft, pairing, users[0], score.wins, score.losses, score.draws)
 			}
 			return nil
 		})
@@ -2166,166 +2374,69 @@ func DiscordReactionRemove(ob *objectbox.ObjectBox) func(s *discordgo.Session, m
 	}
 }
 
+// CheckNextRoundPairings pairs the next round once every match in round is confirmed, or announces the
+// winner if that was the last round.
 func CheckNextRoundPairings(ob *objectbox.ObjectBox, draft *schema.Draft, round int) {
-	results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draft.Id), schema.Result_.Round.LessOrEqual(round)).Find()
+	err := checkDraftState(draft, "pair matches", func(state lifecycle.State) bool {
+		return state == lifecycle.Playing
+	})
 	if err != nil {
 		log.Printf("%s", err.Error())
 		return
 	}
-	numSeats, _ := getNumSeatsAndCardsPerPack(draft)
-	if len(results) == round*numSeats {
-		var users []*schema.User
-		var wins [8]int
-		for _, result := range results {
-			userIndex := slices.IndexFunc(users, func(user *schema.User) bool {
-				return user.Id == result.User.Id
-			})
-			if userIndex <= -1 {
-				userIndex = len(users)
-				users = append(users, result.User)
-			}
-			if result.Win {
-				wins[userIndex]++
-			}
-		}
-		var table1 []string
-		var table2 []string
-		var table3 []string
-		var table4 []string
-		if round == 1 {
-			// Pair round 2
-			for i, user := range users {
-				seat := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-					return seat.User.Id == user.Id
-				})
-				if seat == -1 {
-					log.Printf("found error for user %d who does not have a seat in draft %d", user.Id, draft.Id)
-					return
-				}
-				var player string
-				if len(user.DiscordId) > 0 {
-					player = fmt.Sprintf("<@%s>", user.DiscordId)
-				} else {
-					player = user.DiscordName
-				}
-				if wins[i] == 1 {
-					if seat%2 == 0 {
-						table1 = append(table1, player)
-					} else {
-						table2 = append(table2, player)
-					}
-				} else {
-					if seat%2 == 0 {
-						table3 = append(table3, player)
-					} else {
-						table4 = append(table4, player)
-					}
-				}
-			}
-		} else if round == 2 {
-			var users []*schema.User
-			var wins [8]int
-			for _, result := range results {
-				userIndex := slices.IndexFunc(users, func(user *schema.User) bool {
-					return user.Id == result.User.Id
-				})
-				if userIndex <= -1 {
-					userIndex = len(users)
-					users = append(users, result.User)
-				}
-				if result.Win {
-					wins[userIndex]++
-				}
-			}
-			for i, user := range users {
-				seat := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-					return seat.User.Id == user.Id
-				})
-				if seat == -1 {
-					log.Printf("found error for user %d who does not have a seat in draft %d", user.Id, draft.Id)
-					return
-				}
-				wins := wins[i]
-				var player string
-				if len(user.DiscordId) > 0 {
-					player = fmt.Sprintf("<@%s>", user.DiscordId)
-				} else {
-					player = user.DiscordName
-				}
-				if wins == 2 {
-					table1 = append(table1, player)
-				} else if wins == 0 {
-					table4 = append(table4, player)
-				} else {
-					if seat < 4 {
-						if len(table2) < 2 {
-							table2 = append(table2, player)
-						} else {
-							table3 = append(table3, player)
-						}
-					} else {
-						if len(table3) < 2 {
-							table3 = append(table3, player)
-						} else {
-							table2 = append(table2, player)
-						}
-					}
-				}
-			}
-		} else if round == 3 {
-			winnerIndex := slices.Index(wins[:], 3)
-			if winnerIndex == -1 {
-				log.Printf("no winner found in draft %d", draft.Id)
-				return
-			}
-			winner := users[winnerIndex]
-			var player string
-			if len(winner.DiscordId) > 0 {
-				player = fmt.Sprintf("<@%s>", winner.DiscordId)
-			} else {
-				player = winner.DiscordName
-			}
-			adminDiscordID, err := GetAdminDiscordId(ob)
-			channelId := os.Getenv("DRAFT_ANNOUNCEMENTS_CHANNEL_ID")
-			message := fmt.Sprintf("Congratulations to %s, winner of *%s*!\n\n"+
-				"All players, please ping <@%s> directly when you're ready to return cards.",
-				player, draft.Name, adminDiscordID)
-			if dg != nil {
-				_, err = dg.ChannelMessageSend(channelId, message)
-				if err != nil {
-					log.Printf("%s", err.Error())
-					return
-				}
-			} else {
-				ignoredDiscordCalls = append(ignoredDiscordCalls, DiscordCall{
-					Type:      "postWinner",
-					ChannelId: channelId,
-					Message:   message,
-				})
-			}
+	matches, err := loadMatches(ob, draft)
+	if err != nil {
+		log.Printf("%s", err.Error())
+		return
+	}
+	if len(matches) == 0 {
+		log.Printf("draft %d has no pairings to check", draft.Id)
+		return
+	}
+	// Only the latest round can finish, so rounds aren't paired twice if results change afterwards.
+	if pairings.LastRound(matches) != round || !pairings.RoundFinished(matches, round) {
+		return
+	}
+
+	if round < draftRounds(draft) {
+		err = pairNextRound(ob, draft)
+		if err != nil {
+			log.Printf("%s", err.Error())
 		}
-		if len(table1) == 2 && len(table2) == 2 && len(table3) == 2 && len(table4) == 2 {
-			pairings := fmt.Sprintf(`%s vs %s
-%s vs %s
-%s vs %s
-%s vs %s`,
-				table1[0], table1[1],
-				table2[0], table2[1],
-				table3[0], table3[1],
-				table4[0], table4[1])
-			err = PostPairings(ob, draft, round+1, pairings)
-			if err != nil {
-				log.Printf("%s", err.Error())
-				return
-			}
+		return
+	}
+
+	standings := pairings.Standings(draftPlayers(draft), matches)
+	winner := draftUsers(draft)[standings[0].Player]
+	err = transitionDraft(ob, draft, lifecycle.Complete)
+	if err != nil {
+		log.Printf("%s", err.Error())
+		return
+	}
+	adminDiscordID, err := GetAdminDiscordId(ob)
+	channelId := os.Getenv("DRAFT_ANNOUNCEMENTS_CHANNEL_ID")
+	message := fmt.Sprintf("Congratulations to %s, winner of *%s*!\n\n"+
+		"All players, please ping <@%s> directly when you're ready to return cards.",
+		discordMention(winner), draft.Name, adminDiscordID)
+	if dg != nil {
+		_, err = dg.ChannelMessageSend(channelId, message)
+		if err != nil {
+			log.Printf("%s", err.Error())
+			return
 		}
+	} else {
+		ignoredDiscordCalls = append(ignoredDiscordCalls, DiscordCall{
+			Type:      "postWinner",
+			ChannelId: channelId,
+			Message:   message,
+		})
 	}
 }
 
 func ArchiveSpectatorChannels(ob *objectbox.ObjectBox) error {
 	if dg != nil {
 		log.Printf("archiving spectator channels")
-		err := ob.RunInWriteTx(func() error {
+		err := runInWriteTx(ob, func() error {
 			draftBox := schema.BoxForDraft(ob)
 			drafts, err := draftBox.Query(schema.Draft_.SpectatorChannelId.NotEquals("", false)).Find()
 			if err != nil {
@@ -2338,7 +2449,8 @@ func ArchiveSpectatorChannels(ob *objectbox.ObjectBox) error {
 				}
 				resultsCount, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draft.Id),
 					schema.Result_.Timestamp.LessOrEqual(threeDaysAgo)).Count()
-				if resultsCount == 24 {
+				// Byes are recorded as results, so every player has one for each round.
+				if resultsCount == uint64(draftRounds(draft)*len(draftPlayers(draft))) {
 					channelId := draft.SpectatorChannelId
 					if len(channelId) > 0 {
 						draft.SpectatorChannelId = ""
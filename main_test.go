@@ -1,6 +1,13 @@
 package main
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
@@ -15,7 +22,12 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/cardstats"
+	"github.com/walkingeyerobot/r38/lifecycle"
 	"github.com/walkingeyerobot/r38/makedraft"
+	"github.com/walkingeyerobot/r38/migrations"
+	"github.com/walkingeyerobot/r38/mtgolog"
+	"github.com/walkingeyerobot/r38/picklog"
 	"github.com/walkingeyerobot/r38/schema"
 	"golang.org/x/net/xsrftoken"
 )
@@ -156,20 +168,7 @@ func findTwoCardsToPick(t *testing.T, ob *objectbox.ObjectBox, seat int, round i
 	return nil, nil
 }
 
-func TestOnlineDraft(t *testing.T) {
-	ob, err := doSetup(t, SEED)
-	if err != nil {
-		t.Errorf("error in setup: %s", err.Error())
-		t.FailNow()
-	}
-	defer ob.Close()
-
-	handlers := NewHandler(ob, false)
-
-	makeDraft(t, handlers, SEED, false, false)
-
-	players, seats := populateDraft(t, handlers, 8)
-
+func completeOnlineDraft(t *testing.T, handlers http.Handler, ob *objectbox.ObjectBox, players []int, seats []int) {
 	for round := range 3 {
 		for card := range 15 {
 			for _, seat := range rand.Perm(8) {
@@ -196,6 +195,119 @@ func TestOnlineDraft(t *testing.T) {
 	}
 }
 
+func TestOnlineDraft(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, seats := populateDraft(t, handlers, 8)
+
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if draft.State != string(lifecycle.Playing) {
+		t.Errorf("expected finished online draft to be %s, but it was %s", lifecycle.Playing, draft.State)
+	}
+}
+
+func TestOnlineDraftRejectsPicksBeforePodIsFull(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/join/?as=3",
+			strings.NewReader(`{"id": 1, "position": 0}`)))
+
+	cardId := findCardToPick(t, ob, 0, 0, 0, false).Id
+	token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(3, 16), "pick1")
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/pick/?as=3",
+			strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+
+	res := w.Result()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected pick to fail before the draft started, but status code was %d", res.StatusCode)
+	}
+}
+
+func TestFirstPickStartsDraftAndArchivedDraftRejectsPicks(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, true, false)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/join/?as=3",
+			strings.NewReader(`{"id": 1, "position": 0}`)))
+
+	pick := func() int {
+		cardId := findCardToPick(t, ob, 0, 0, 0, true).CardId
+		token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(3, 16), "pick1")
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", "/api/pickrfid/?as=3",
+				strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cardRfids": ["%s"], "xsrfToken": "%s"}`, cardId, token))))
+		return w.Result().StatusCode
+	}
+
+	status := pick()
+	if status != http.StatusOK {
+		t.Errorf("expected first pick to succeed, but status code was %d", status)
+	}
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.State != string(lifecycle.Drafting) {
+		t.Errorf("expected first pick to move draft to %s, but it was %s", lifecycle.Drafting, draft.State)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/archive/1?as=1", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("expected draft in progress to be archived, but status code was %d", w.Result().StatusCode)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/archive/1?as=1", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("expected archiving an archived draft to do nothing, but status code was %d", w.Result().StatusCode)
+	}
+
+	status = pick()
+	if status != http.StatusBadRequest {
+		t.Errorf("expected pick in archived draft to fail, but status code was %d", status)
+	}
+}
+
 func TestOnlinePickTwoDraft(t *testing.T) {
 	ob, err := doSetup(t, SEED)
 	if err != nil {
@@ -765,6 +877,8 @@ func TestOnlineDraftNotifiesPlayerOfPicksAvailable(t *testing.T) {
 	makeDraft(t, handlers, SEED, false, false)
 
 	players, seats := populateDraft(t, handlers, 8)
+	// Ignore the pings sent when the draft started.
+	ignoredDiscordCalls = nil
 
 	player := players[0] + 1
 	seat := seats[0]
@@ -797,6 +911,8 @@ func TestOnlineDraftDoesNotNotifyPlayerOfPicksWhenPassingPlayerHasMorePacks(t *t
 	makeDraft(t, handlers, SEED, false, false)
 
 	players, seats := populateDraft(t, handlers, 8)
+	// Ignore the pings sent when the draft started.
+	ignoredDiscordCalls = nil
 
 	player := players[0] + 1
 	seat := seats[0]
@@ -858,3 +974,2061 @@ func TestOnlineDraftLocksSpectatorChannelOnJoin(t *testing.T) {
 		t.Error("didn't lock channel")
 	}
 }
+
+func TestOnlineDraftNotifiesEveryoneWhenPodFills(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, _ := populateDraft(t, handlers, 8)
+
+	for _, player := range players[:8] {
+		if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+			return call.Type == "notify" && strings.HasPrefix(call.Message, fmt.Sprintf("<@%d> ", player+1))
+		}) {
+			t.Errorf("didn't notify player %d that the draft started", player+1)
+		}
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if draft.StartedAt.IsZero() {
+		t.Error("didn't stamp draft start time")
+	}
+}
+
+func TestAdminCanStartDraftBeforePodIsFull(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	handlers.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest("POST", "/api/join/?as=3",
+			strings.NewReader(`{"id": 1, "position": 0}`)))
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/start/1?as=3", nil))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Error("non-admin was allowed to start the draft")
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/start/1", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("admin couldn't start the draft: %s", body)
+	}
+
+	cardId := findCardToPick(t, ob, 0, 0, 0, false).Id
+	token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(3, 16), "pick1")
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/pick/?as=3",
+			strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("pick failed after the draft started: %s", body)
+	}
+}
+
+func TestScheduledDraftOpensAndStartsOnSchedule(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/makedraft/?as=1",
+			strings.NewReader(fmt.Sprintf(`{
+				"name": "scheduled draft",
+				"seed": %d,
+				"openAt": "%s",
+				"startAt": "%s"
+			}`, SEED, time.Now().Add(time.Hour).Format(time.RFC3339), time.Now().Add(48*time.Hour).Format(time.RFC3339)))))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("error making draft: %s", body)
+		t.FailNow()
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/join/?as=3",
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Error("joined a draft before it opened")
+	}
+
+	draftBox := schema.BoxForDraft(ob)
+	draft, err := draftBox.Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	draft.OpenAt = time.Now().Add(-time.Minute)
+	_, err = draftBox.Put(draft)
+	if err != nil {
+		t.Errorf("couldn't update draft: %s", err.Error())
+		t.FailNow()
+	}
+
+	err = ProcessScheduledDrafts(ob)
+	if err != nil {
+		t.Errorf("error processing scheduled drafts: %s", err.Error())
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "notifyEmbed" && strings.Contains(call.Message, "scheduled draft is open!")
+	}) {
+		t.Error("didn't announce the draft opening")
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/join/?as=3",
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("couldn't join an open draft: %s", body)
+	}
+
+	draft, err = draftBox.Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	draft.StartAt = time.Now().Add(30 * time.Minute)
+	_, err = draftBox.Put(draft)
+	if err != nil {
+		t.Errorf("couldn't update draft: %s", err.Error())
+		t.FailNow()
+	}
+
+	err = ProcessScheduledDrafts(ob)
+	if err != nil {
+		t.Errorf("error processing scheduled drafts: %s", err.Error())
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "notify" && strings.HasPrefix(call.Message, "<@3> *scheduled draft* starts")
+	}) {
+		t.Error("didn't remind joined player")
+	}
+
+	draft, err = draftBox.Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	draft.StartAt = time.Now().Add(-time.Minute)
+	_, err = draftBox.Put(draft)
+	if err != nil {
+		t.Errorf("couldn't update draft: %s", err.Error())
+		t.FailNow()
+	}
+
+	err = ProcessScheduledDrafts(ob)
+	if err != nil {
+		t.Errorf("error processing scheduled drafts: %s", err.Error())
+	}
+	draft, err = draftBox.Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if draft.State != string(lifecycle.Open) {
+		t.Errorf("expected draft with empty seats to wait past its start time, but it was %s", draft.State)
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "notify" && strings.HasPrefix(call.Message, "<@1> draft 1 was due to start, but only 1 of 8 seats are filled")
+	}) {
+		t.Error("didn't tell the admin the draft couldn't start")
+	}
+
+	for player := 4; player <= 10; player++ {
+		w = httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/join/?as=%d", player),
+				strings.NewReader(`{"id": 1}`)))
+		if w.Result().StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(w.Result().Body)
+			t.Errorf("couldn't join an open draft: %s", body)
+		}
+	}
+	draft, err = draftBox.Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if draft.State != string(lifecycle.Drafting) {
+		t.Errorf("expected draft to start once it filled after its start time, but it was %s", draft.State)
+	}
+}
+
+func TestWaitlistedUserIsPromotedWhenSeatFreesUp(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, _ := populateDraft(t, handlers, 8)
+	leaver := players[0] + 1
+	waiter := players[8] + 1
+
+	entry, err := GetDraftListEntry(int64(waiter), ob, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "waitlist" {
+		t.Errorf("expected the full draft to offer a waitlist, got %q", entry.Status)
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/waitlist/?as=%d", waiter),
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("couldn't join waitlist: %s", body)
+		t.FailNow()
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "waitlisted" || entry.WaitlistLength != 1 {
+		t.Errorf("expected to be shown on the waitlist, got %+v", entry)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/leave/?as=%d", leaver),
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("couldn't leave draft: %s", body)
+		t.FailNow()
+	}
+
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "directMessage" && call.ChannelId == strconv.Itoa(waiter)
+	}) {
+		t.Error("didn't message waitlisted user about their reservation")
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if len(draft.Waitlist) != 0 {
+		t.Errorf("waitlisted user wasn't removed from the waitlist")
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/join/?as=%d", leaver),
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Error("another user took the reserved seat")
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/join/?as=%d", waiter),
+			strings.NewReader(`{"id": 1}`)))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("waitlisted user couldn't claim their seat: %s", body)
+	}
+}
+
+func TestSeatsAreOnlyAssignedToUsersWhoOptedIntoFormat(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	for user := 2; user <= 11; user++ {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/setpref/?as=%d", user),
+				strings.NewReader(`{"pref": {"format": "cube", "elig": true}}`)))
+		if w.Result().StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(w.Result().Body)
+			t.Errorf("couldn't set pref: %s", body)
+			t.FailNow()
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/prefs/?as=2", nil))
+	var prefs UserFormatPrefs
+	err = json.Unmarshal(w.Body.Bytes(), &prefs)
+	if err != nil {
+		t.Errorf("couldn't parse prefs: %s", err.Error())
+		t.FailNow()
+	}
+	if !slices.Contains(prefs.Prefs, UserFormatPref{Format: "cube", Name: "cube", Elig: true}) {
+		t.Errorf("cube pref wasn't saved: %v", prefs.Prefs)
+	}
+
+	// Users 2 through 9 play a draft, so user 10 is the one who has waited longest.
+	makeDraft(t, handlers, SEED, false, false)
+	for user := 2; user <= 9; user++ {
+		w = httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/join/?as=%d", user),
+				strings.NewReader(`{"id": 1}`)))
+		if w.Result().StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(w.Result().Body)
+			t.Errorf("couldn't join draft: %s", body)
+			t.FailNow()
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/setpref/?as=11",
+			strings.NewReader(`{"pref": {"format": "cube", "elig": false}}`)))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("couldn't set pref: %s", body)
+		t.FailNow()
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", "/api/makedraft/?as=1",
+			strings.NewReader(fmt.Sprintf(`{
+				"name": "assigned draft",
+				"seed": %d,
+				"assignSeats": true
+			}`, SEED))))
+	if w.Result().StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(w.Result().Body)
+		t.Errorf("error making draft: %s", body)
+		t.FailNow()
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(2)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	var reserved []uint64
+	for _, seat := range draft.Seats {
+		if seat.ReservedUser != nil {
+			reserved = append(reserved, seat.ReservedUser.Id)
+		}
+	}
+	if len(reserved) != 8 {
+		t.Errorf("expected 8 reserved seats, got %v", reserved)
+	}
+	if !slices.Contains(reserved, 10) {
+		t.Errorf("user who never played didn't get a seat: %v", reserved)
+	}
+	for _, user := range []uint64{1, 11, 12} {
+		if slices.Contains(reserved, user) {
+			t.Errorf("user %d got a seat without opting into the format", user)
+		}
+	}
+}
+
+func TestDeckRegistration(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, seats := populateDraft(t, handlers, 8)
+	player := players[0] + 1
+	otherPlayer := players[1] + 1
+
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/deck/1?as=%d", player), nil))
+	var deck DeckJSON
+	err = json.Unmarshal(w.Body.Bytes(), &deck)
+	if err != nil {
+		t.Errorf("couldn't parse deck: %s", err.Error())
+		t.FailNow()
+	}
+	if len(deck.MainDeck) != 0 || len(deck.Sideboard) != 45 {
+		t.Errorf("expected unregistered deck to be all sideboard, got %d main and %d sideboard",
+			len(deck.MainDeck), len(deck.Sideboard))
+	}
+
+	postDeck := func(as int, mainDeck []int64, basics string, lock bool) *http.Response {
+		cards, _ := json.Marshal(mainDeck)
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/deck/1?as=%d", as),
+				strings.NewReader(fmt.Sprintf(`{"mainDeck": %s, "basics": %s, "lock": %t}`, cards, basics, lock))))
+		return w.Result()
+	}
+
+	if res := postDeck(player, deck.Sideboard[:23], `{"Plains": 16}`, true); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 39 card deck to be rejected, got status %d", res.StatusCode)
+	}
+	if res := postDeck(otherPlayer, deck.Sideboard[:23], `{"Plains": 17}`, true); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected deck with cards from someone else's pool to be rejected, got status %d", res.StatusCode)
+	}
+	if res := postDeck(player, deck.Sideboard[:23], `{"Wastes": 17}`, true); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected deck with unknown basics to be rejected, got status %d", res.StatusCode)
+	}
+	if res := postDeck(player, deck.Sideboard[:23], `{"Plains": 9, "Island": 8}`, true); res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		t.Errorf("couldn't lock deck: %s", body)
+		t.FailNow()
+	}
+	if res := postDeck(player, deck.Sideboard[:22], `{"Plains": 9, "Island": 9}`, false); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected locked deck to stay locked, got status %d", res.StatusCode)
+	}
+
+	getReplay := func() DraftJSON {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/draft/1?as=12", nil))
+		var replay DraftJSON
+		err := json.Unmarshal(w.Body.Bytes(), &replay)
+		if err != nil {
+			t.Errorf("couldn't parse replay: %s", err.Error())
+			t.FailNow()
+		}
+		return replay
+	}
+
+	for _, seat := range getReplay().Seats {
+		if seat.Deck != nil {
+			t.Errorf("deck shown in replay while matches are being played: %+v", seat.Deck)
+		}
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft.State = string(lifecycle.Complete)
+	_, err = schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, seat := range getReplay().Seats {
+		switch seat.PlayerID {
+		case int64(player):
+			if seat.Deck == nil || len(seat.Deck.MainDeck) != 23 || seat.Deck.Basics["Island"] != 8 {
+				t.Errorf("registered deck missing from replay: %+v", seat.Deck)
+			}
+		case int64(otherPlayer):
+			if seat.Deck != nil {
+				t.Errorf("unregistered deck shown in replay: %+v", seat.Deck)
+			}
+		}
+	}
+}
+
+func TestDekExportReportsCardsWithoutMtgoIds(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, seats := populateDraft(t, handlers, 8)
+	player := players[0] + 1
+
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	// None of the cards in the test cube have MTGO IDs.
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/export/dek/1?as=%d", player), nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("couldn't export pool: %s", w.Body.String())
+		t.FailNow()
+	}
+	if missing := w.Result().Header.Get("X-Missing-Mtgo-Ids"); missing != "45" {
+		t.Errorf("expected 45 cards reported missing, got %s", missing)
+	}
+	if !strings.HasSuffix(w.Body.String(), "</Deck>\n") {
+		t.Errorf("malformed .dek file: %s", w.Body.String())
+	}
+	dek := w.Body.String()
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/export/1?format=mtgo&as=%d", player), nil))
+	if w.Result().StatusCode != http.StatusOK || w.Body.String() != dek {
+		t.Errorf("expected the mtgo format to match /api/export/dek, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/export/1?format=arena&as=%d", player), nil))
+	if w.Result().StatusCode != http.StatusOK || !strings.HasPrefix(w.Body.String(), "Deck\n") {
+		t.Errorf("couldn't export pool for Arena: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/export/dekzip/1?as=%d", player), nil))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Error("non-admin was able to export every pool")
+	}
+
+	// Two players going by the same name still get a file each.
+	for _, twin := range players[:2] {
+		user, err := schema.BoxForUser(ob).Get(uint64(twin + 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		user.MtgoName = "Twin"
+		_, err = schema.BoxForUser(ob).Put(user)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/export/dekzip/1", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("couldn't export every pool: %s", w.Body.String())
+		t.FailNow()
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Errorf("couldn't read zip: %s", err.Error())
+		t.FailNow()
+	}
+	dekFiles := make(map[string]bool)
+	var reportedMissing bool
+	for _, file := range zipReader.File {
+		if strings.HasSuffix(file.Name, ".dek") {
+			dekFiles[file.Name] = true
+		} else if file.Name == "missing-mtgo-ids.txt" {
+			reportedMissing = true
+		}
+	}
+	if len(dekFiles) != 8 {
+		t.Errorf("expected 8 differently named .dek files, got %v", dekFiles)
+	}
+	if !reportedMissing {
+		t.Error("cards without MTGO IDs weren't reported")
+	}
+}
+
+func TestPickDatasetReconstructsPacks(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, seats := populateDraft(t, handlers, 8)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/export/picks/?draft=1", nil))
+	if w.Result().StatusCode != http.StatusBadRequest {
+		t.Errorf("expected picks in an unfinished draft to stay hidden, got status %d", w.Result().StatusCode)
+	}
+
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/export/picks/?draft=1&format=jsonl", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("couldn't export picks: %s", w.Body.String())
+		t.FailNow()
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 8*45 {
+		t.Errorf("expected %d picks, got %d", 8*45, len(lines))
+	}
+	for _, line := range lines {
+		var row picklog.Row
+		err = json.Unmarshal([]byte(line), &row)
+		if err != nil {
+			t.Errorf("couldn't parse row %s: %s", line, err.Error())
+			t.FailNow()
+		}
+		if len(row.Pack) != 16-row.PickNumber {
+			t.Errorf("expected %d cards in pack at pick %d, got %d", 16-row.PickNumber, row.PickNumber, len(row.Pack))
+		}
+		if !slices.Contains(row.Pack, row.Card) {
+			t.Errorf("picked card %s wasn't in the pack %v", row.Card, row.Pack)
+		}
+		if row.Format != "cube" {
+			t.Errorf("expected format cube, got %s", row.Format)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/export/picks/", nil))
+	if lines := strings.Count(w.Body.String(), "\n"); lines != 8*45+1 {
+		t.Errorf("expected header and %d picks in CSV, got %d lines", 8*45, lines)
+	}
+}
+
+func TestImportMtgoDraftLog(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	draftLog := `Event #: 3141592
+Time:    10/2/2021 8:12:34 PM
+Players:
+    Alice
+--> Bob
+    Carol
+
+------ MID ------
+
+Pack 1 pick 1:
+    Augur of Autumn
+--> Brutal Cathar
+    Consider
+
+Pack 1 pick 2:
+--> Play with Fire
+    Duel for Dominance
+
+Pack 1 pick 3:
+    Thermo-Alchemist
+--> Fateful Absence
+
+Pack 1 pick 4:
+--> Augur of Autumn
+`
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/import/mtgo/?as=2", strings.NewReader(draftLog)))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("couldn't import draft log: %s", w.Body.String())
+		t.FailNow()
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Errorf("couldn't get draft: %s", err.Error())
+		t.FailNow()
+	}
+	if draft.State != string(lifecycle.Complete) || draft.Format != "mid" || len(draft.Seats) != 3 {
+		t.Errorf("imported draft set up wrong: state %s, format %s, %d seats", draft.State, draft.Format, len(draft.Seats))
+	}
+	if len(draft.Events) != 4 {
+		t.Errorf("expected 4 picks, got %d", len(draft.Events))
+	}
+	for _, seat := range draft.Seats {
+		if seat.Position == 1 {
+			if seat.User == nil || seat.User.Id != 2 || len(seat.PickedCards) != 4 {
+				t.Errorf("drafter's seat set up wrong: %+v", seat)
+			}
+			// Bob opened the first pack and saw it again on the fourth pick.
+			if len(seat.OriginalPacks) != 1 || len(seat.OriginalPacks[0].OriginalCards) != 3 {
+				t.Errorf("expected Bob's own pack to have the 3 cards he first saw: %+v", seat.OriginalPacks)
+			}
+		} else if seat.User != nil {
+			t.Errorf("expected placeholder seat at position %d, got user %d", seat.Position, seat.User.Id)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/userstats/?as=2", nil))
+	var stats UserStats
+	err = json.Unmarshal(w.Body.Bytes(), &stats)
+	if err != nil {
+		t.Errorf("couldn't parse user stats: %s", err.Error())
+		t.FailNow()
+	}
+	if stats.CompletedDrafts != 1 {
+		t.Errorf("expected imported draft to count as completed, got %d", stats.CompletedDrafts)
+	}
+}
+
+func TestExportMtgoDraftLog(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	players, seats := populateDraft(t, handlers, 8)
+	player := players[0] + 1
+	otherPlayer := players[1] + 1
+
+	for card := range 2 {
+		for _, seat := range rand.Perm(8) {
+			cardId := findCardToPick(t, ob, seats[seat], 0, card, false).Id
+			token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(players[seat]+1), 16), "pick1")
+			w := httptest.NewRecorder()
+			handlers.ServeHTTP(w,
+				httptest.NewRequest("POST", fmt.Sprintf("/api/pick/?as=%d", players[seat]+1),
+					strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+			if w.Result().StatusCode != http.StatusOK {
+				t.Errorf("pick failed: %s", w.Body.String())
+				t.FailNow()
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/export/mtgolog/1?as=%d", player), nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("couldn't export draft log: %s", w.Body.String())
+		t.FailNow()
+	}
+	draftLog, err := mtgolog.Parse(strings.NewReader(w.Body.String()))
+	if err != nil {
+		t.Errorf("couldn't parse exported draft log: %s\n%s", err.Error(), w.Body.String())
+		t.FailNow()
+	}
+	if len(draftLog.Players) != 8 || len(draftLog.Picks) != 2 {
+		t.Errorf("expected 8 players and 2 picks, got %d and %d", len(draftLog.Players), len(draftLog.Picks))
+	}
+	if len(draftLog.Picks[0].Cards) != 15 || len(draftLog.Picks[1].Cards) != 14 {
+		t.Errorf("expected packs of 15 and 14 cards, got %d and %d",
+			len(draftLog.Picks[0].Cards), len(draftLog.Picks[1].Cards))
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET",
+		fmt.Sprintf("/api/export/mtgolog/1?seat=%d&as=%d", seats[0], otherPlayer), nil))
+	if w.Result().StatusCode != http.StatusBadRequest {
+		t.Errorf("expected other players' logs to be hidden during the draft, got status %d", w.Result().StatusCode)
+	}
+}
+
+// makeSwissDraft makes a draft whose picks are done, seating users 2 onwards, and pairs its first round.
+func makeSwissDraft(t *testing.T, ob *objectbox.ObjectBox, numPlayers int) *schema.Draft {
+	var seats []*schema.Seat
+	for position := range numPlayers {
+		user, err := schema.BoxForUser(ob).Get(uint64(position + 2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		seats = append(seats, &schema.Seat{Position: position, User: user, Round: 4})
+	}
+	draftId, err := schema.BoxForDraft(ob).Put(&schema.Draft{
+		Name:  "swiss draft",
+		Seats: seats,
+		State: string(lifecycle.Deckbuilding),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft, err := schema.BoxForDraft(ob).Get(draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = PostFirstRoundPairings(ob, draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return draft
+}
+
+// reportRound reports every match of a round as won 2-1 by the player with the lower user ID.
+func reportRound(t *testing.T, ob *objectbox.ObjectBox, draftId uint64, round int) []*schema.Pairing {
+	pairingRows, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draftId),
+		schema.Pairing_.Round.Equals(round)).Find()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pairing := range pairingRows {
+		if pairing.Player2 == nil {
+			continue
+		}
+		if pairing.Player1.Id < pairing.Player2.Id {
+			reportMatch(t, ob, draftId, pairing, 2, 1, 0)
+		} else {
+			reportMatch(t, ob, draftId, pairing, 1, 2, 0)
+		}
+	}
+	return pairingRows
+}
+
+// reportMatch has the first player in a match report its score and the second player confirm it.
+func reportMatch(t *testing.T, ob *objectbox.ObjectBox, draftId uint64, pairing *schema.Pairing, wins1, wins2, draws int) {
+	draft, err := schema.BoxForDraft(ob).Get(draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reportResult(ob, draft, pairing, pairing.Player1, wins1, wins2, draws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = confirmResult(ob, draft, pairing, pairing.Player2)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSwissPairingsWithByes(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	draftId := makeSwissDraft(t, ob, 5).Id
+
+	byes := make(map[uint64]bool)
+	played := make(map[[2]uint64]bool)
+	for round := 1; round <= 3; round++ {
+		pairingRows := reportRound(t, ob, draftId, round)
+		if len(pairingRows) != 3 {
+			t.Fatalf("expected two matches and a bye in round %d, got %d pairings", round, len(pairingRows))
+		}
+		for _, pairing := range pairingRows {
+			if pairing.Player2 == nil {
+				if byes[pairing.Player1.Id] {
+					t.Errorf("user %d got a second bye in round %d", pairing.Player1.Id, round)
+				}
+				byes[pairing.Player1.Id] = true
+				continue
+			}
+			key := [2]uint64{min(pairing.Player1.Id, pairing.Player2.Id), max(pairing.Player1.Id, pairing.Player2.Id)}
+			if played[key] {
+				t.Errorf("rematch between users %d and %d in round %d", key[0], key[1], round)
+			}
+			played[key] = true
+		}
+	}
+
+	draft, err := schema.BoxForDraft(ob).Get(draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.State != string(lifecycle.Complete) {
+		t.Errorf("expected draft to be %s after three rounds, but it was %s", lifecycle.Complete, draft.State)
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "postWinner" && strings.Contains(call.Message, "<@2>")
+	}) {
+		t.Errorf("expected user 2 to be announced as the winner, got %+v", ignoredDiscordCalls)
+	}
+
+	// Byes count towards standings but aren't matches anyone played.
+	records, err := picklog.LoadRecords(ob, draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var matchesPlayed int
+	for _, record := range records {
+		matchesPlayed += record.Wins + record.Losses + record.Draws
+	}
+	if matchesPlayed != 12 {
+		t.Errorf("expected 12 match results without byes, got %d: %+v", matchesPlayed, records)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	draftId := makeSwissDraft(t, ob, 4).Id
+	reportRound(t, ob, draftId, 1)
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/standings/%d?as=3", draftId), nil))
+	res := w.Result()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		t.Fatalf("error getting standings: %s", body)
+	}
+	var standings Standings
+	err = json.NewDecoder(res.Body).Decode(&standings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if standings.Rounds != 2 || standings.CurrentRound != 2 || len(standings.Pairings) != 2 {
+		t.Fatalf("expected round 2 of 2 to be paired, got %+v", standings)
+	}
+	// Seats are paired across the table: users 2 and 3 beat users 4 and 5, then play each other.
+	for _, match := range standings.Pairings[0].Matches {
+		if !match.Reported || match.Wins1 != 2 || match.Player2 == nil || match.Player2.ID != match.Player1.ID+2 {
+			t.Errorf("unexpected round 1 match %+v", match)
+		}
+	}
+	for _, match := range standings.Pairings[1].Matches {
+		if match.Reported {
+			t.Errorf("round 2 match shouldn't be reported yet: %+v", match)
+		}
+	}
+	if len(standings.Standings) != 4 {
+		t.Fatalf("expected four players in the standings, got %d", len(standings.Standings))
+	}
+	leader := standings.Standings[0]
+	if leader.Rank != 1 || leader.Player.ID != 2 || leader.Points != 3 || leader.MatchWins != 1 ||
+		leader.CurrentOpponent == nil || leader.CurrentOpponent.ID != 3 {
+		t.Errorf("expected user 2 to lead and be playing user 3, got %+v", leader)
+	}
+	last := standings.Standings[3]
+	if last.MatchLosses != 1 || last.GameLosses != 2 || last.OpponentMatchWinPct != 1 {
+		t.Errorf("unexpected last place %+v", last)
+	}
+}
+
+func TestMatchResultsNeedConfirming(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	// Users 2 and 4 play each other, as do users 3 and 5.
+	draftId := makeSwissDraft(t, ob, 4).Id
+
+	postResult := func(user int, body string) (int, MatchJSON) {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("POST",
+			fmt.Sprintf("/api/result/%d?as=%d", draftId, user), strings.NewReader(body)))
+		res := w.Result()
+		var match MatchJSON
+		if res.StatusCode == http.StatusOK {
+			err := json.NewDecoder(res.Body).Decode(&match)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return res.StatusCode, match
+	}
+
+	status, _ := postResult(2, `{"action": "report", "wins": 3, "losses": 0}`)
+	if status != http.StatusBadRequest {
+		t.Errorf("expected an impossible score to be rejected, got %d", status)
+	}
+	status, match := postResult(2, `{"action": "report", "wins": 2, "losses": 1}`)
+	if status != http.StatusOK || match.Status != ResultReported || match.Reported {
+		t.Fatalf("expected report to wait for confirmation, got %d %+v", status, match)
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "directMessage" && call.ChannelId == "4"
+	}) {
+		t.Errorf("expected user 4 to be asked to confirm the result")
+	}
+	status, _ = postResult(2, `{"action": "confirm"}`)
+	if status != http.StatusBadRequest {
+		t.Errorf("expected reporter confirming their own result to fail, got %d", status)
+	}
+	status, match = postResult(4, `{"action": "confirm"}`)
+	if status != http.StatusOK || match.Status != ResultConfirmed || !match.Reported || match.Wins1 != 2 ||
+		match.Wins2 != 1 {
+		t.Fatalf("expected 2-1 result to be confirmed, got %d %+v", status, match)
+	}
+	results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draftId)).Find()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if result.User.Id == 4 && (result.Win || result.GamesWon != 1 || result.GamesLost != 2) {
+			t.Errorf("expected user 4 to have lost 1-2, got %+v", result)
+		}
+	}
+
+	// Reports that don't agree on the score are a dispute, and the round doesn't advance until the admin
+	// settles it.
+	ignoredDiscordCalls = nil
+	postResult(3, `{"action": "report", "wins": 2, "losses": 0}`)
+	status, match = postResult(5, `{"action": "report", "wins": 1, "losses": 2}`)
+	if status != http.StatusOK || match.Status != ResultDisputed {
+		t.Fatalf("expected contradictory reports to be disputed, got %d %+v", status, match)
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "notify" && strings.Contains(call.Message, "disagree")
+	}) {
+		t.Errorf("expected the admin to be told about the dispute, got %+v", ignoredDiscordCalls)
+	}
+	status, _ = postResult(3, `{"action": "report", "wins": 1, "losses": 2}`)
+	if status != http.StatusBadRequest {
+		t.Errorf("expected reporting a disputed result to fail, got %d", status)
+	}
+	pairingCount, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draftId)).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairingCount != 2 {
+		t.Errorf("expected round 2 not to be paired during a dispute, but there are %d pairings", pairingCount)
+	}
+
+	status, _ = postResult(3, `{"action": "resolve", "user": 5, "wins": 2, "losses": 1}`)
+	if status == http.StatusOK {
+		t.Errorf("expected players not to be able to settle disputes")
+	}
+	status, match = postResult(1, `{"action": "resolve", "user": 5, "wins": 2, "losses": 1}`)
+	if status != http.StatusOK || match.Status != ResultConfirmed || match.Wins1 != 1 || match.Wins2 != 2 {
+		t.Fatalf("expected admin to settle the dispute in user 5's favor, got %d %+v", status, match)
+	}
+	pairingCount, err = schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draftId),
+		schema.Pairing_.Round.Equals(2)).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairingCount != 2 {
+		t.Errorf("expected round 2 to be paired once every result was confirmed, got %d pairings", pairingCount)
+	}
+}
+
+func TestWithdrawResultOnlyTakesBackTheCurrentReport(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	draft := makeSwissDraft(t, ob, 4)
+	pairingRows, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draft.Id),
+		schema.Pairing_.Round.Equals(1)).Find()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairing := pairingRows[0]
+	reporter := pairing.Player2
+
+	// The reporter changes their mind from 2-0 to 2-1, then takes back the stale 2-0.
+	for _, score := range [][2]int{{2, 0}, {2, 1}} {
+		err = reportResult(ob, draft, pairing, reporter, score[0], score[1], 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = withdrawResult(ob, draft, pairing, reporter, 2, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairing.Status != ResultReported || pairing.Wins2 != 2 || pairing.Wins1 != 1 {
+		t.Errorf("expected the 2-1 report to stand, got %+v", pairing)
+	}
+
+	err = withdrawResult(ob, draft, pairing, reporter, 2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairing.Status != ResultPending || pairing.ReportedBy != nil {
+		t.Errorf("expected the 2-1 report to be withdrawn, got %+v", pairing)
+	}
+
+	err = reportResult(ob, draft, pairing, reporter, 2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = transitionDraft(ob, draft, lifecycle.Complete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = withdrawResult(ob, draft, pairing, reporter, 2, 1, 0)
+	if !errors.Is(err, DraftStateError) {
+		t.Errorf("expected withdrawing from a finished draft to fail, got %v", err)
+	}
+}
+
+func TestSeasonLeaderboard(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	get := func(path string, v any) {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		res := w.Result()
+		if res.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(res.Body)
+			t.Fatalf("error getting %s: %s", path, body)
+		}
+		err := json.NewDecoder(res.Body).Decode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, season := range []string{
+		`{"name": "Old Season", "startAt": "2020-01-01T00:00:00Z", "endAt": "2021-01-01T00:00:00Z"}`,
+		fmt.Sprintf(`{"name": "This Season", "startAt": "%s"}`, time.Now().Add(-24*time.Hour).Format(time.RFC3339)),
+	} {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/addseason/?as=2", strings.NewReader(season)))
+		if w.Result().StatusCode == http.StatusOK {
+			t.Errorf("expected only the admin to be able to add seasons")
+		}
+		w = httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("POST", "/api/addseason/?as=1", strings.NewReader(season)))
+		if w.Result().StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(w.Result().Body)
+			t.Fatalf("error adding season: %s", body)
+		}
+	}
+	var seasons []SeasonJSON
+	get("/api/seasons/?as=3", &seasons)
+	if len(seasons) != 2 || seasons[1].Name != "This Season" || seasons[1].EndAt != nil {
+		t.Fatalf("expected two seasons, got %+v", seasons)
+	}
+
+	// Users 2 and 3 win round 1, then user 2 beats user 3.
+	draftId := makeSwissDraft(t, ob, 4).Id
+	reportRound(t, ob, draftId, 1)
+	reportRound(t, ob, draftId, 2)
+
+	var leaderboard Leaderboard
+	get(fmt.Sprintf("/api/leaderboard/%d?as=3", seasons[1].ID), &leaderboard)
+	if leaderboard.Season == nil || leaderboard.Season.ID != seasons[1].ID || len(leaderboard.Entries) != 4 {
+		t.Fatalf("expected four players on this season's leaderboard, got %+v", leaderboard)
+	}
+	leader := leaderboard.Entries[0]
+	if leader.Player.ID != 2 || leader.Points != 6 || leader.Wins != 2 || leader.Drafts != 1 || leader.Rating <= 1500 {
+		t.Errorf("expected user 2 to lead with two wins, got %+v", leader)
+	}
+	last := leaderboard.Entries[3]
+	if last.Points != 0 || last.Rating >= 1500 {
+		t.Errorf("expected last place to have no points and a lower rating, got %+v", last)
+	}
+
+	get(fmt.Sprintf("/api/leaderboard/%d?as=3", seasons[0].ID), &leaderboard)
+	if len(leaderboard.Entries) != 0 {
+		t.Errorf("expected nobody on the old season's leaderboard, got %+v", leaderboard.Entries)
+	}
+
+	var history PlayerHistory
+	get("/api/playerhistory/2?as=3", &history)
+	if len(history.Results) != 2 || history.Results[1].Opponent == nil || history.Results[1].Opponent.ID != 3 ||
+		history.Results[1].RatingBefore != history.Results[0].RatingAfter || history.Rating != history.Results[1].RatingAfter {
+		t.Errorf("expected user 2's history to show both rounds, got %+v", history)
+	}
+
+	ignoredDiscordCalls = nil
+	err = AnnounceLeaderboard(ob, "leaderboard-channel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(ignoredDiscordCalls, func(call DiscordCall) bool {
+		return call.Type == "notifyEmbed" && call.ChannelId == "leaderboard-channel" &&
+			strings.Contains(call.Message, "This Season") && strings.Contains(call.Message, "1. <@2>: 6 points (2-0)")
+	}) {
+		t.Errorf("expected the season's leaderboard to be announced, got %+v", ignoredDiscordCalls)
+	}
+}
+
+func TestUserStats(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	cards := []*schema.Card{
+		{Data: `{"scryfall":{"name":"Goblin Guide","colors":["R"],"rarity":"common"}}`},
+		{Data: `{"scryfall":{"name":"Rare Bird","colors":["U"],"rarity":"rare"}}`},
+		{Data: `{"scryfall":{"name":"Wall of Air","colors":["U"],"rarity":"uncommon"}}`},
+	}
+	_, err = schema.BoxForCard(ob).PutMany(cards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := &schema.Pack{Round: 1, OriginalCards: cards}
+	_, err = schema.BoxForPack(ob).Put(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seats []*schema.Seat
+	for position := range 3 {
+		user, err := schema.BoxForUser(ob).Get(uint64(position + 2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		seats = append(seats, &schema.Seat{Position: position, User: user, Round: 4})
+	}
+	seats[0].PickedCards = cards
+	var events []*schema.Event
+	for _, card := range cards {
+		events = append(events, &schema.Event{Position: 0, Round: 1, Card1: card, Pack: pack})
+	}
+	draftId, err := schema.BoxForDraft(ob).Put(&schema.Draft{
+		Name:   "stats draft",
+		Format: "dsk",
+		Seats:  seats,
+		Events: events,
+		State:  string(lifecycle.Deckbuilding),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft, err := schema.BoxForDraft(ob).Get(draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = PostFirstRoundPairings(ob, draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// User 2 registers a deck of just the blue cards.
+	_, err = schema.BoxForDeck(ob).Put(&schema.Deck{
+		Seat:     draft.Seats[slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool { return seat.Position == 0 })],
+		MainDeck: cards[1:],
+		Locked:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// User 2 beats user 3 while user 4 has a bye, then beats user 4.
+	reportRound(t, ob, draftId, 1)
+	reportRound(t, ob, draftId, 2)
+
+	user2, err := schema.BoxForUser(ob).Get(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = schema.BoxForDraft(ob).Put(&schema.Draft{
+		Name:  "unfinished draft",
+		Seats: []*schema.Seat{{Position: 0, User: user2}},
+		State: string(lifecycle.Drafting),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getStats := func(path string) UserStats {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Result().StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(w.Result().Body)
+			t.Fatalf("error getting %s: %s", path, body)
+		}
+		var stats UserStats
+		err := json.Unmarshal(w.Body.Bytes(), &stats)
+		if err != nil {
+			t.Fatalf("couldn't parse user stats: %s", err.Error())
+		}
+		return stats
+	}
+
+	stats := getStats("/api/userstats/?as=2")
+	if stats.Player.ID != 2 || stats.CompletedDrafts != 1 || stats.ActiveDrafts != 1 || len(stats.History) != 2 {
+		t.Errorf("expected one finished and one unfinished draft, got %+v", stats)
+	}
+	if stats.Matches != (MatchRecord{Wins: 2, WinRate: 1}) || stats.Trophies != 1 {
+		t.Errorf("expected user 2 to go 2-0 for a trophy, got %+v with %d trophies", stats.Matches, stats.Trophies)
+	}
+	if stats.MatchesByColors["U"].Wins != 2 || stats.MatchesByFormat["dsk"].Wins != 2 {
+		t.Errorf("expected wins to be grouped by U and dsk, got %+v and %+v", stats.MatchesByColors, stats.MatchesByFormat)
+	}
+	if !slices.Equal(stats.DraftedColors, []int{0, 2, 0, 1, 0, 0}) || !slices.Equal(stats.FirstPickColors, []int{0, 0, 0, 1, 0, 0}) {
+		t.Errorf("expected two blue cards and a red first pick, got %v and %v", stats.DraftedColors, stats.FirstPickColors)
+	}
+	if stats.RaresPicked != 1 || stats.RareAveragePick != 2 {
+		t.Errorf("expected the rare to be taken second, got %d rares at %f", stats.RaresPicked, stats.RareAveragePick)
+	}
+	if len(stats.MostPicked) != 3 || stats.MostPicked[0] != (CardCount{Name: "Goblin Guide", Count: 1}) {
+		t.Errorf("expected each card to be picked once, got %+v", stats.MostPicked)
+	}
+	history := stats.History[0]
+	if history.DraftID != int64(draftId) || history.Colors != "U" || history.Picks != 3 || history.Wins != 2 || !history.Trophy {
+		t.Errorf("draft history set up wrong: %+v", history)
+	}
+
+	// Others see the colors of user 2's whole pool until the draft is over.
+	stats = getStats("/api/userstats/?as=3&user=2")
+	if stats.Player.ID != 2 || len(stats.History) != 1 || stats.History[0].DraftID != int64(draftId) {
+		t.Errorf("expected other users to only see user 2's finished draft, got %+v", stats.History)
+	}
+	if stats.History[0].Colors != "UR" || stats.MatchesByColors["U"].Wins != 0 {
+		t.Errorf("expected user 2's deck to stay hidden while matches are played, got %+v", stats)
+	}
+	draft, err = schema.BoxForDraft(ob).Get(draftId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft.State = string(lifecycle.Complete)
+	_, err = schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats = getStats("/api/userstats/?as=3&user=2")
+	if stats.History[0].Colors != "U" || stats.MatchesByColors["U"].Wins != 2 {
+		t.Errorf("expected user 2's deck to show once the draft is over, got %+v", stats)
+	}
+
+	stats = getStats("/api/userstats/?as=2&user=4")
+	if stats.Matches != (MatchRecord{Losses: 1}) || stats.History[0].Byes != 1 || stats.Trophies != 0 {
+		t.Errorf("expected user 4's bye not to count as a match, got %+v", stats)
+	}
+}
+
+func TestCardStats(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+	players, seats := populateDraft(t, handlers, 8)
+
+	getStats := func() []cardstats.Stats {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/cardstats/cube", nil))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("couldn't get card stats: %s", w.Body.String())
+		}
+		var stats []cardstats.Stats
+		err := json.Unmarshal(w.Body.Bytes(), &stats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stats
+	}
+	if stats := getStats(); len(stats) != 0 {
+		t.Errorf("expected unfinished drafts to be left out, got %d cards", len(stats))
+	}
+
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	taken := 0
+	for _, card := range getStats() {
+		taken += card.Taken
+		if card.Taken > card.Seen || card.TakenPick < 1 || card.TakenPick > 15 || card.LastSeenPick < 1 {
+			t.Errorf("card stats don't add up: %+v", card)
+		}
+	}
+	if taken != 8*45 {
+		t.Errorf("expected %d cards taken, got %d", 8*45, taken)
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/cardstats/..%2Fmain", nil))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Errorf("expected an unknown format to be rejected")
+	}
+}
+
+func TestHeadToHead(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	// Users 2 and 3 meet in round 2 of a Swiss draft, and user 2 wins 2-1.
+	swissDraftId := makeSwissDraft(t, ob, 4).Id
+	reportRound(t, ob, swissDraftId, 1)
+	reportRound(t, ob, swissDraftId, 2)
+
+	// An older eight-player draft was paired by the fixed bracket, and its pairings are rebuilt from its
+	// results. User 2 beats user 6 in round 1, user 4 in round 2 and user 3 in round 3.
+	var seats []*schema.Seat
+	users := make(map[uint64]*schema.User)
+	for position := range 8 {
+		user, err := schema.BoxForUser(ob).Get(uint64(position + 2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[user.Id] = user
+		seats = append(seats, &schema.Seat{Position: position, User: user})
+	}
+	legacyDraft := &schema.Draft{Name: "legacy draft", Seats: seats, State: string(lifecycle.Complete)}
+	_, err = schema.BoxForDraft(ob).Put(legacyDraft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for round := 1; round <= 3; round++ {
+		_, err = schema.BoxForPairingMsg(ob).Put(&schema.PairingMsg{Draft: legacyDraft, Round: round})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, result := range []struct {
+		round int
+		user  uint64
+		win   bool
+	}{
+		{1, 2, true}, {1, 6, false}, {1, 3, true}, {1, 7, false}, {1, 4, true}, {1, 8, false}, {1, 5, true}, {1, 9, false},
+		{2, 2, true}, {2, 4, false}, {2, 3, true}, {2, 5, false}, {2, 6, true}, {2, 8, false}, {2, 7, true}, {2, 9, false},
+		{3, 2, true}, {3, 3, false},
+	} {
+		_, err = schema.BoxForResult(ob).Put(&schema.Result{
+			Draft:     legacyDraft,
+			Round:     result.round,
+			User:      users[result.user],
+			Win:       result.win,
+			Timestamp: time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = migrations.RunObjectBoxMigrations(ob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getHeadToHead := func(path string) HeadToHead {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("error getting %s: %s", path, w.Body.String())
+		}
+		var headToHead HeadToHead
+		err := json.Unmarshal(w.Body.Bytes(), &headToHead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return headToHead
+	}
+
+	headToHead := getHeadToHead("/api/headtohead/3?as=2")
+	if headToHead.Player.ID != 2 || headToHead.Opponent.ID != 3 || headToHead.Wins != 2 || headToHead.Losses != 0 ||
+		headToHead.GameWins != 4 || headToHead.GameLosses != 1 || len(headToHead.Drafts) != 2 {
+		t.Fatalf("expected user 2 to be 2-0 against user 3 over two drafts, got %+v", headToHead)
+	}
+	swiss := headToHead.Drafts[0]
+	if swiss.DraftID != int64(swissDraftId) || len(swiss.Matches) != 1 || swiss.Matches[0].Round != 2 {
+		t.Errorf("expected the Swiss match to come from its pairing, got %+v", swiss)
+	}
+	if swiss.PlayerDeck != nil || swiss.OpponentDeck != nil {
+		t.Errorf("expected decks to stay hidden while the Swiss draft is being played, got %+v", swiss)
+	}
+	legacy := headToHead.Drafts[1]
+	if legacy.DraftID != int64(legacyDraft.Id) || len(legacy.Matches) != 1 || legacy.Matches[0].Round != 3 {
+		t.Errorf("expected the legacy match to be rebuilt in round 3, got %+v", legacy)
+	}
+	if legacy.PlayerDeck == nil || legacy.OpponentDeck == nil {
+		t.Errorf("expected decks from the finished legacy draft, got %+v", legacy)
+	}
+
+	headToHead = getHeadToHead("/api/headtohead/3/2?as=7")
+	if headToHead.Player.ID != 3 || headToHead.Losses != 2 || headToHead.GameWins != 1 || headToHead.GameLosses != 4 {
+		t.Errorf("expected user 3 to be 0-2 against user 2, got %+v", headToHead)
+	}
+	headToHead = getHeadToHead("/api/headtohead/2/4?as=7")
+	if headToHead.Wins != 2 || len(headToHead.Drafts) != 2 || headToHead.Drafts[1].Matches[0].Round != 2 {
+		t.Errorf("expected user 2 to have beaten user 4 in both drafts, got %+v", headToHead)
+	}
+	headToHead = getHeadToHead("/api/headtohead/2/5?as=7")
+	if len(headToHead.Drafts) != 0 {
+		t.Errorf("expected users 2 and 5 never to have played, got %+v", headToHead)
+	}
+
+	ignoredDiscordCalls = nil
+	err = AnswerHeadToHead(ob, "chat", "2", "!h2h <@3>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = AnswerHeadToHead(ob, "chat", "9", "!h2h <@!2> <@5>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ignoredDiscordCalls) != 2 ||
+		!strings.HasPrefix(ignoredDiscordCalls[0].Message, "<@2> vs <@3>: 2-0 in matches, 4-1 in games") ||
+		!strings.Contains(ignoredDiscordCalls[0].Message, "won 2-1 in round 2") ||
+		ignoredDiscordCalls[1].Message != "<@2> and <@5> haven't played each other yet." {
+		t.Errorf("unexpected head-to-head answers: %+v", ignoredDiscordCalls)
+	}
+}
+
+func TestDraftTiming(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+	players, seats := populateDraft(t, handlers, 8)
+	completeOnlineDraft(t, handlers, ob, players, seats)
+
+	// Pretend the first pick was made before picks were timed.
+	draft, err := schema.BoxForDraft(ob).Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstEvent := slices.MinFunc(draft.Events, func(a, b *schema.Event) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	firstEvent.Timestamp = time.Time{}
+	_, err = schema.BoxForEvent(ob).Put(firstEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	draftJson, err := GetJSONObject(ob, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untimed := 0
+	for _, event := range draftJson.Events {
+		if event.Timestamp == nil {
+			untimed++
+		}
+	}
+	if untimed != 1 {
+		t.Errorf("expected only the first pick to have no timestamp, got %d", untimed)
+	}
+
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/drafttiming/1", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("couldn't get draft timing: %s", w.Body.String())
+	}
+	var timing DraftTiming
+	err = json.Unmarshal(w.Body.Bytes(), &timing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Neither the untimed pick, the next hold of its pack nor the drafter's next hold can be timed.
+	if !timing.Finished || timing.StartedAt == nil || timing.LastPickAt == nil || timing.TimedPicks != 8*45-3 {
+		t.Errorf("expected a finished draft with all but three picks timed, got %+v", timing)
+	}
+	if timing.DurationSeconds < 0 || timing.LastPickAt.Before(*timing.StartedAt) || len(timing.SlowestHolds) != 5 ||
+		timing.SlowestHolds[0].Seconds < timing.SlowestHolds[4].Seconds {
+		t.Errorf("draft timing doesn't add up: %+v", timing)
+	}
+	if len(timing.Players) != 8 {
+		t.Fatalf("expected timing for 8 players, got %d", len(timing.Players))
+	}
+	for _, player := range timing.Players {
+		if player.Player == nil || player.Slowest == nil || player.TimedPicks < 43 || player.MedianPickSeconds > player.Slowest.Seconds {
+			t.Errorf("player timing doesn't add up: %+v", player)
+		}
+	}
+}
+
+func TestDraftProgress(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+	players, seats := populateDraft(t, handlers, 8)
+
+	// One player picks, passing their pack to the next seat.
+	cardId := findCardToPick(t, ob, seats[0], 0, 0, false).Id
+	token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(players[0]+1), 16), "pick1")
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/pick/?as=%d", players[0]+1),
+			strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("pick failed: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/draftprogress/1?as=3", nil))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("couldn't get draft progress: %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "cards") {
+		t.Errorf("draft progress shouldn't reveal cards: %s", w.Body.String())
+	}
+	var progress DraftProgress
+	err = json.Unmarshal(w.Body.Bytes(), &progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.State != string(lifecycle.Drafting) || len(progress.Seats) != 8 || progress.Bottleneck != nil {
+		t.Errorf("expected 8 seats drafting with no bottleneck, got %+v", progress)
+	}
+	for _, seat := range progress.Seats {
+		picks, queued := 0, 1
+		switch seat.Position {
+		case seats[0]:
+			picks, queued = 1, 0
+		case (seats[0] + 1) % 8:
+			queued = 2
+		}
+		if seat.Player == nil || seat.Round != 1 || seat.Picks != picks || seat.PacksQueued != queued ||
+			(queued > 0) != (seat.OldestQueuedAt != nil) || seat.OldestQueuedSeconds < 0 {
+			t.Errorf("unexpected progress for position %d: %+v", seat.Position, seat)
+		}
+	}
+
+	// Everyone but the player in position 0 has moved on to the second round.
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	user := &schema.User{Id: 2, DiscordId: "2"}
+	slowPack := &schema.Pack{Id: 2, Round: 1}
+	draft := &schema.Draft{
+		Id:        2,
+		State:     string(lifecycle.Drafting),
+		StartedAt: start,
+		Seats: []*schema.Seat{{
+			Position: 0,
+			User:     user,
+			Round:    1,
+			Packs:    []*schema.Pack{{Id: 1, Round: 1}, slowPack, {Id: 10, Round: 2}},
+		}},
+		Events: []*schema.Event{
+			{Id: 1, Position: 1, Round: 1, Timestamp: start.Add(5 * time.Minute)},
+			{Id: 2, Position: 7, Round: 1, Pack: slowPack, Timestamp: start.Add(10 * time.Minute)},
+		},
+	}
+	for position := 1; position < 8; position++ {
+		draft.Seats = append(draft.Seats, &schema.Seat{
+			Position: position,
+			User:     &schema.User{Id: uint64(position + 2)},
+			Round:    2,
+			Packs:    []*schema.Pack{{Id: uint64(position + 10), Round: 2}},
+		})
+	}
+	progress = getDraftProgress(draft, start.Add(30*time.Minute))
+	if progress.Bottleneck == nil || progress.Bottleneck.Position != 0 || !progress.Seats[0].Blocking ||
+		progress.Bottleneck.PacksQueued != 2 || progress.Bottleneck.OldestQueuedSeconds != 30*60 {
+		t.Errorf("expected position 0 to be holding up the draft for 30 minutes, got %+v", progress.Bottleneck)
+	}
+	if progress.Seats[1].Blocking || progress.Seats[1].OldestQueuedAt == nil ||
+		!progress.Seats[1].OldestQueuedAt.Equal(start.Add(5*time.Minute)) {
+		t.Errorf("expected position 1's pack to be waiting since it finished the first round, got %+v", progress.Seats[1])
+	}
+
+	draft.Seats[3].Round = 1
+	draft.Seats[3].Packs = []*schema.Pack{{Id: 20, Round: 1}}
+	if blocking := findBlockingSeat(draft); blocking != nil {
+		t.Errorf("expected no single blocking seat with two players behind, got position %d", blocking.Position)
+	}
+}
+
+func TestDraftUpdates(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+	server := httptest.NewServer(handlers)
+	defer server.Close()
+
+	makeDraft(t, handlers, SEED, false, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/draftupdates/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("couldn't watch draft: %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	stream := bufio.NewReader(res.Body)
+	line, err := stream.ReadString('\n')
+	if err != nil || line != ": watching draft 1\n" {
+		t.Fatalf("expected the stream to start watching, got %q (%v)", line, err)
+	}
+	updates := make(chan DraftUpdate)
+	go func() {
+		eventType := ""
+		for {
+			line, err := stream.ReadString('\n')
+			if err != nil {
+				close(updates)
+				return
+			}
+			if after, ok := strings.CutPrefix(line, "event: "); ok {
+				eventType = strings.TrimSpace(after)
+			} else if after, ok := strings.CutPrefix(line, "data: "); ok {
+				var update DraftUpdate
+				err = json.Unmarshal([]byte(after), &update)
+				if err != nil || update.Type != eventType {
+					t.Errorf("bad update %q for event %s: %v", after, eventType, err)
+				}
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	nextUpdate := func() DraftUpdate {
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a draft update")
+		}
+		return DraftUpdate{}
+	}
+
+	players, seats := populateDraft(t, handlers, 8)
+	for range 8 {
+		if update := nextUpdate(); update.Type != DraftUpdateJoin || update.DraftID != 1 {
+			t.Errorf("expected a join update, got %+v", update)
+		}
+	}
+	if update := nextUpdate(); update.Type != DraftUpdateState {
+		t.Errorf("expected the draft starting to be an update, got %+v", update)
+	}
+
+	// A pick that's rolled back shouldn't be announced.
+	player := players[0] + 1
+	cardId := findCardToPick(t, ob, seats[0], 0, 0, false).Id
+	pick := func(token string) int {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/pick/?as=%d", player),
+				strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+		return w.Result().StatusCode
+	}
+	if status := pick("bad token"); status == http.StatusOK {
+		t.Fatal("expected a pick with a bad token to fail")
+	}
+	token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(player), 16), "pick1")
+	if status := pick(token); status != http.StatusOK {
+		t.Fatalf("pick failed with status %d", status)
+	}
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/undopick/?as=%d", player),
+			strings.NewReader(fmt.Sprintf(`{"draftId": 1, "xsrfToken": "%s"}`, token))))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("undo failed: %s", w.Body.String())
+	}
+	if update := nextUpdate(); update.Type != DraftUpdatePick || update.Modified != 1 {
+		t.Errorf("expected the pick to be the first update, got %+v", update)
+	}
+	if update := nextUpdate(); update.Type != DraftUpdateUndo || update.Modified != 2 {
+		t.Errorf("expected the undo to follow the pick, got %+v", update)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", "/api/draftupdates/99", nil))
+	if w.Result().StatusCode != http.StatusNotFound {
+		t.Errorf("expected watching a missing draft to fail, got %d", w.Result().StatusCode)
+	}
+}
+
+func TestIncrementalDraft(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+	players, seats := populateDraft(t, handlers, 8)
+	// Someone without a seat in the full draft spectates, level with the slowest drafter.
+	viewer := players[8] + 1
+
+	pick := func(i int, card int) {
+		player := players[i] + 1
+		cardId := findCardToPick(t, ob, seats[i], 0, card, false).Id
+		token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(player), 16), "pick1")
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/pick/?as=%d", player),
+				strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("pick failed: %s", w.Body.String())
+		}
+	}
+	type incrementalDraft struct {
+		DraftJSON
+		Since *int64 `json:"since"`
+	}
+	getDraft := func(query string) incrementalDraft {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/draft/1?as=%d%s", viewer, query), nil))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("couldn't get draft with %q: %s", query, w.Body.String())
+		}
+		var draft incrementalDraft
+		err := json.Unmarshal(w.Body.Bytes(), &draft)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return draft
+	}
+	countPacks := func(draft incrementalDraft) int {
+		packs := 0
+		for _, seat := range draft.Seats {
+			for _, pack := range seat.Packs {
+				if pack != nil {
+					packs++
+				}
+			}
+		}
+		return packs
+	}
+
+	for i := range 8 {
+		pick(i, 0)
+	}
+	draft := getDraft("")
+	if draft.Modified != 8 || len(draft.Events) != 8 || draft.Since != nil || countPacks(draft) != 24 {
+		t.Errorf("expected the whole draft after the first picks, got modified %d, %d events and %d packs",
+			draft.Modified, len(draft.Events), countPacks(draft))
+	}
+
+	draft = getDraft("&since=8")
+	if draft.Since == nil || *draft.Since != 8 || draft.FullRefresh || len(draft.Events) != 0 || countPacks(draft) != 0 ||
+		len(draft.Seats) != 8 || draft.Seats[seats[0]].PlayerID != int64(players[0]+1) {
+		t.Errorf("expected only seat metadata with nothing new, got %+v", draft)
+	}
+
+	for i := range 8 {
+		pick(i, 1)
+	}
+	draft = getDraft("&since=8")
+	if draft.FullRefresh || draft.Modified != 16 || len(draft.Events) != 8 || draft.Events[0].DraftModified != 9 ||
+		draft.Events[0].Position != int64(seats[0]) || countPacks(draft) != 0 {
+		t.Errorf("expected only the second picks, got %+v", draft)
+	}
+
+	// Undoing a pick invalidates what the client has.
+	player := players[7] + 1
+	token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(player), 16), "pick1")
+	w := httptest.NewRecorder()
+	handlers.ServeHTTP(w,
+		httptest.NewRequest("POST", fmt.Sprintf("/api/undopick/?as=%d", player),
+			strings.NewReader(fmt.Sprintf(`{"draftId": 1, "xsrfToken": "%s"}`, token))))
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("undo failed: %s", w.Body.String())
+	}
+	// The spectator is now back level with the drafter who undid their pick.
+	draft = getDraft("&since=16")
+	if !draft.FullRefresh || draft.Modified != 8 || len(draft.Events) != 8 || countPacks(draft) != 24 {
+		t.Errorf("expected a full refresh after an undo, got modified %d, %d events and %d packs",
+			draft.Modified, len(draft.Events), countPacks(draft))
+	}
+	// Spectators kept behind the undone pick never saw it, so they carry on without a full refresh.
+	if draft = getDraft("&since=8"); draft.FullRefresh || len(draft.Events) != 0 {
+		t.Errorf("expected nothing new for a spectator behind the undone pick, got %+v", draft.Events)
+	}
+
+	// Picks after an undo don't reuse its DraftModified value.
+	pick(7, 1)
+	draft = getDraft("&since=8")
+	if draft.FullRefresh || draft.Modified != 18 || len(draft.Events) != 8 ||
+		draft.Events[len(draft.Events)-1].DraftModified != 18 {
+		t.Errorf("expected the new pick after the undo, got %+v", draft.Events)
+	}
+	draft = getDraft("&since=18")
+	if draft.FullRefresh || len(draft.Events) != 0 {
+		t.Errorf("expected nothing new once caught up, got %+v", draft.Events)
+	}
+
+	w = httptest.NewRecorder()
+	handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/draft/1?as=%d&since=soon", viewer), nil))
+	if w.Result().StatusCode == http.StatusOK {
+		t.Errorf("expected a bad since value to fail")
+	}
+}
+
+func TestSpectatorDelay(t *testing.T) {
+	ob, err := doSetup(t, SEED)
+	if err != nil {
+		t.Errorf("error in setup: %s", err.Error())
+		t.FailNow()
+	}
+	defer ob.Close()
+
+	handlers := NewHandler(ob, false)
+
+	makeDraft(t, handlers, SEED, false, false)
+	players, seats := populateDraft(t, handlers, 8)
+	viewer := players[8] + 1
+
+	pick := func(i int, card int) {
+		player := players[i] + 1
+		cardId := findCardToPick(t, ob, seats[i], 0, card, false).Id
+		token := xsrftoken.Generate(xsrfKey, strconv.FormatInt(int64(player), 16), "pick1")
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w,
+			httptest.NewRequest("POST", fmt.Sprintf("/api/pick/?as=%d", player),
+				strings.NewReader(fmt.Sprintf(`{"draftId": 1, "cards": [%d], "xsrfToken": "%s"}`, cardId, token))))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("pick failed: %s", w.Body.String())
+		}
+	}
+	getReplay := func() DraftJSON {
+		w := httptest.NewRecorder()
+		handlers.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/api/draft/1?as=%d", viewer), nil))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("couldn't get draft: %s", w.Body.String())
+		}
+		var replay DraftJSON
+		err := json.Unmarshal(w.Body.Bytes(), &replay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return replay
+	}
+	hiddenPacks := func(replay DraftJSON) int {
+		hidden := 0
+		for _, seat := range replay.Seats {
+			for _, pack := range seat.Packs {
+				if len(pack) > 0 && pack[0].(map[string]interface{})["hidden"] == true {
+					hidden++
+				}
+			}
+		}
+		return hidden
+	}
+	updateDraft := func(update func(draft *schema.Draft)) {
+		draft, err := schema.BoxForDraft(ob).Get(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(draft)
+		_, err = schema.BoxForDraft(ob).Put(draft)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, user := range []int64{0, int64(players[0] + 1)} {
+		access, err := getReplayAccess(ob, 1, user)
+		if err != nil || access != filteredReplay {
+			t.Errorf("expected user %d to only see their own picks, got %d (%v)", user, access, err)
+		}
+	}
+	access, err := getReplayAccess(ob, 1, int64(viewer))
+	if err != nil || access != delayedReplay {
+		t.Errorf("expected a spectator's replay to be delayed, got %d (%v)", access, err)
+	}
+
+	// Half the table is a pick ahead of the rest.
+	for i := range 8 {
+		pick(i, 0)
+	}
+	for i := range 4 {
+		pick(i, 1)
+	}
+	replay := getReplay()
+	if replay.Modified != 8 || len(replay.Events) != 8 || hiddenPacks(replay) != 16 {
+		t.Errorf("expected spectators to see only the first picks and first packs, got modified %d, %d events and %d hidden packs",
+			replay.Modified, len(replay.Events), hiddenPacks(replay))
+	}
+
+	updateDraft(func(draft *schema.Draft) {
+		draft.SpectatorDelayPicks = 1
+	})
+	replay = getReplay()
+	if replay.Modified != 0 || len(replay.Events) != 0 || hiddenPacks(replay) != 24 {
+		t.Errorf("expected spectators a pick behind to see nothing yet, got modified %d, %d events and %d hidden packs",
+			replay.Modified, len(replay.Events), hiddenPacks(replay))
+	}
+
+	updateDraft(func(draft *schema.Draft) {
+		draft.SpectatorDelayPicks = 0
+		draft.SpectatorDelaySeconds = 3600
+		for _, event := range draft.Events {
+			if event.Modified <= 2 {
+				event.Timestamp = time.Now().Add(-2 * time.Hour)
+				_, err := schema.BoxForEvent(ob).Put(event)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	})
+	replay = getReplay()
+	if replay.Modified != 2 || len(replay.Events) != 2 || hiddenPacks(replay) != 22 {
+		t.Errorf("expected spectators an hour behind to see the two old picks, got modified %d, %d events and %d hidden packs",
+			replay.Modified, len(replay.Events), hiddenPacks(replay))
+	}
+
+	// Once the draft is over, spectators see everything.
+	updateDraft(func(draft *schema.Draft) {
+		draft.State = string(lifecycle.Deckbuilding)
+	})
+	replay = getReplay()
+	if replay.Modified != 12 || len(replay.Events) != 12 || hiddenPacks(replay) != 0 {
+		t.Errorf("expected the full replay once the draft is over, got modified %d, %d events and %d hidden packs",
+			replay.Modified, len(replay.Events), hiddenPacks(replay))
+	}
+}
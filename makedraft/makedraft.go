@@ -1,6 +1,7 @@
 package makedraft
 
 import (
+	"cmp"
 	"encoding/csv"
 	"encoding/json"
 	"flag"
@@ -20,6 +21,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/walkingeyerobot/r38/draftconfig"
+	"github.com/walkingeyerobot/r38/lifecycle"
 	"github.com/walkingeyerobot/r38/schema"
 )
 
@@ -56,6 +58,11 @@ type Settings struct {
 	AbortDuplicateThreeColorIdentityUncommons *bool
 	PickTwo                                   *bool
 	UpdateExisting                            *uint64
+	OpenAt                                    *string
+	StartAt                                   *string
+	Rounds                                    *int
+	SpectatorDelayPicks                       *int
+	SpectatorDelay                            *string
 }
 
 func ParseSettings(args []string) (Settings, error) {
@@ -95,6 +102,21 @@ func ParseSettings(args []string) (Settings, error) {
 	settings.UpdateExisting = flagSet.Uint64(
 		"updateExisting", 0,
 		"If nonzero, updates cards in an existing draft rather than creating a new one.")
+	settings.OpenAt = flagSet.String(
+		"openAt", "",
+		"If set, an RFC 3339 time at which the draft opens for joining. Until then it is only scheduled.")
+	settings.StartAt = flagSet.String(
+		"startAt", "",
+		"If set, an RFC 3339 time at which the draft starts if the pod is full. If it isn't, the admin is told, and the draft starts once the pod fills or the admin starts it.")
+	settings.Rounds = flagSet.Int(
+		"rounds", 0,
+		"The number of rounds of Swiss played after an online draft. If 0, enough rounds are played for one player to finish undefeated.")
+	settings.SpectatorDelayPicks = flagSet.Int(
+		"spectatorDelayPicks", 0,
+		"How many picks behind the slowest drafter spectators are kept until the draft is over.")
+	settings.SpectatorDelay = flagSet.String(
+		"spectatorDelay", "",
+		"If set, a duration such as 10m that picks must be older than before spectators see them.")
 
 	err := flagSet.Parse(args[1:])
 
@@ -125,6 +147,19 @@ func MakeDraft(settings Settings, ob *objectbox.ObjectBox) error {
 
 	log.Printf("generating draft %s.", *settings.Name)
 
+	openAt, err := parseScheduledTime(settings.OpenAt)
+	if err != nil {
+		return fmt.Errorf("bad openAt: %w", err)
+	}
+	startAt, err := parseScheduledTime(settings.StartAt)
+	if err != nil {
+		return fmt.Errorf("bad startAt: %w", err)
+	}
+	state := lifecycle.Open
+	if openAt.After(time.Now()) {
+		state = lifecycle.Scheduled
+	}
+
 	var numPacks int
 	if *settings.PickTwo {
 		numPacks = 12
@@ -161,7 +196,7 @@ func MakeDraft(settings Settings, ob *objectbox.ObjectBox) error {
 	} else {
 		numUsers = 0
 	}
-	assignedUsers, err := AssignSeats(ob, 0, numUsers)
+	assignedUsers, err := AssignSeats(ob, 0, format, numUsers)
 	if err != nil {
 		return err
 	}
@@ -273,6 +308,28 @@ func MakeDraft(settings Settings, ob *objectbox.ObjectBox) error {
 		Events:             []*schema.Event{},
 		SpectatorChannelId: channelID,
 		PickTwo:            *settings.PickTwo,
+		State:              string(state),
+		OpenAt:             openAt,
+		StartAt:            startAt,
+	}
+	if settings.Rounds != nil {
+		if *settings.Rounds < 0 {
+			return fmt.Errorf("can't play %d rounds", *settings.Rounds)
+		}
+		draft.Rounds = *settings.Rounds
+	}
+	if settings.SpectatorDelayPicks != nil {
+		if *settings.SpectatorDelayPicks < 0 {
+			return fmt.Errorf("can't delay spectators by %d picks", *settings.SpectatorDelayPicks)
+		}
+		draft.SpectatorDelayPicks = *settings.SpectatorDelayPicks
+	}
+	if settings.SpectatorDelay != nil && *settings.SpectatorDelay != "" {
+		delay, err := time.ParseDuration(*settings.SpectatorDelay)
+		if err != nil || delay < 0 {
+			return fmt.Errorf("bad spectatorDelay %q", *settings.SpectatorDelay)
+		}
+		draft.SpectatorDelaySeconds = int(delay.Seconds())
 	}
 
 	draftId, err := schema.BoxForDraft(ob).Put(&draft)
@@ -297,6 +354,14 @@ func MakeDraft(settings Settings, ob *objectbox.ObjectBox) error {
 	return nil
 }
 
+// parseScheduledTime parses an optional RFC 3339 time, returning the zero time if it's unset.
+func parseScheduledTime(value *string) (time.Time, error) {
+	if value == nil || *value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, *value)
+}
+
 func getRNG(settings Settings) *rand.Rand {
 	var random *rand.Rand
 	if *settings.Seed == 0 {
@@ -817,48 +882,69 @@ func okDraft(packs [24][15]draftconfig.Card, settings Settings) bool {
 	return passes
 }
 
-func AssignSeats(ob *objectbox.ObjectBox, draftId int64, numUsers int) ([]int, error) {
+// AssignSeats picks up to numUsers users to reserve seats for in a draft of the given format.
+// Only users who opted into the format are considered, and users whose most recent draft is
+// oldest go first. If draftId is set, users who skipped or are already seated in it are left out.
+func AssignSeats(ob *objectbox.ObjectBox, draftId int64, format string, numUsers int) ([]int, error) {
 	var userIds []int
 	if numUsers == 0 {
 		return userIds, nil
 	}
 
+	var draft *schema.Draft
 	if draftId != 0 {
-		draft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+		var err error
+		draft, err = schema.BoxForDraft(ob).Get(uint64(draftId))
 		if err != nil {
 			return userIds, err
 		}
+	}
 
-		users, err := schema.BoxForUser(ob).GetAll() // TODO: need to limit?
+	users, err := schema.BoxForUser(ob).Query(schema.User_.FormatPrefs.Link(
+		schema.FormatPref_.Format.Equals(format, true),
+		schema.FormatPref_.Elig.Equals(true),
+	)).Find()
+	if err != nil {
+		return userIds, err
+	}
+
+	lastPlayed := make(map[uint64]uint64)
+	for _, user := range users {
+		draftIds, err := schema.BoxForDraft(ob).Query(
+			schema.Draft_.Seats.Link(schema.Seat_.User.Equals(user.Id)),
+		).FindIds()
 		if err != nil {
 			return userIds, err
 		}
-		candidates := rand.Perm(len(users))
-		for _, i := range candidates {
-			if slices.IndexFunc(users[i].Skips, func(skip *schema.Skip) bool {
+		if len(draftIds) > 0 {
+			lastPlayed[user.Id] = slices.Max(draftIds)
+		}
+	}
+	// Shuffle first so that users who last played in the same draft are picked in random order.
+	rand.Shuffle(len(users), func(i, j int) {
+		users[i], users[j] = users[j], users[i]
+	})
+	slices.SortStableFunc(users, func(a, b *schema.User) int {
+		return cmp.Compare(lastPlayed[a.Id], lastPlayed[b.Id])
+	})
+
+	for _, user := range users {
+		if draft != nil {
+			if slices.ContainsFunc(user.Skips, func(skip *schema.Skip) bool {
 				return skip.DraftId == uint64(draftId)
-			}) != -1 {
+			}) {
 				continue
 			}
-			userId := users[i].Id
-			if slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
-				return seat.User.Id == userId || seat.ReservedUser.Id == userId
-			}) != -1 {
+			if slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
+				return (seat.User != nil && seat.User.Id == user.Id) ||
+					(seat.ReservedUser != nil && seat.ReservedUser.Id == user.Id)
+			}) {
 				continue
 			}
-			userIds = append(userIds, int(userId))
-			if len(userIds) == numUsers {
-				break
-			}
 		}
-	} else {
-		users, err := schema.BoxForUser(ob).GetAll() // TODO: need to limit?
-		if err != nil {
-			return userIds, err
-		}
-		candidates := rand.Perm(len(users))
-		for _, i := range candidates {
-			userIds = append(userIds, int(users[i].Id))
+		userIds = append(userIds, int(user.Id))
+		if len(userIds) == numUsers {
+			break
 		}
 	}
 
@@ -0,0 +1,262 @@
+package migrations
+
+import (
+	"cmp"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/pairings"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ObjectBoxMigrations are run against the ObjectBox store on startup.
+// ObjectBox adds and removes properties on its own, so these only need to fill in
+// data for existing objects. Each of them must be safe to run more than once.
+var ObjectBoxMigrations = []func(ob *objectbox.ObjectBox) error{
+	backfillDraftStates,
+	backfillPairings,
+	backfillResultGames,
+	backfillPairingResults,
+}
+
+// RunObjectBoxMigrations runs every ObjectBox migration in a single transaction.
+func RunObjectBoxMigrations(ob *objectbox.ObjectBox) error {
+	return ob.RunInWriteTx(func() error {
+		for _, migrate := range ObjectBoxMigrations {
+			err := migrate(ob)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillDraftStates infers a lifecycle state for drafts created before states were stored.
+func backfillDraftStates(ob *objectbox.ObjectBox) error {
+	draftBox := schema.BoxForDraft(ob)
+	drafts, err := draftBox.Query(schema.Draft_.State.Equals("", true)).Find()
+	if err != nil {
+		return err
+	}
+	if len(drafts) == 0 {
+		return nil
+	}
+	for _, draft := range drafts {
+		finished := !slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
+			return seat.Round <= 3
+		})
+		full := !slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
+			return seat.User == nil
+		})
+		var state lifecycle.State
+		if draft.Archived {
+			state = lifecycle.Archived
+		} else if !finished {
+			if full || len(draft.Events) > 0 {
+				state = lifecycle.Drafting
+			} else {
+				state = lifecycle.Open
+			}
+		} else {
+			pairingMsgs, err := schema.BoxForPairingMsg(ob).Query(schema.PairingMsg_.Draft.Equals(draft.Id)).Count()
+			if err != nil {
+				return err
+			}
+			results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draft.Id)).Count()
+			if err != nil {
+				return err
+			}
+			if pairingMsgs == 0 {
+				state = lifecycle.Deckbuilding
+			} else if results >= uint64(3*len(draft.Seats)) {
+				state = lifecycle.Complete
+			} else {
+				state = lifecycle.Playing
+			}
+		}
+		log.Printf("backfilling draft %d state as %s", draft.Id, state)
+		draft.State = string(state)
+	}
+	_, err = draftBox.PutMany(drafts)
+	return err
+}
+
+// backfillPairings rebuilds the pairings of drafts that were paired before pairings were stored, by
+// replaying the fixed bracket they were paired with. Every round that had its pairings posted is
+// rebuilt: round 1 from the seats and later rounds from the results before them.
+func backfillPairings(ob *objectbox.ObjectBox) error {
+	pairingMsgs, err := schema.BoxForPairingMsg(ob).GetAll()
+	if err != nil {
+		return err
+	}
+	lastRounds := make(map[uint64]int)
+	for _, msg := range pairingMsgs {
+		if msg.Draft != nil {
+			lastRounds[msg.Draft.Id] = max(lastRounds[msg.Draft.Id], msg.Round)
+		}
+	}
+	draftIDs := slices.Sorted(maps.Keys(lastRounds))
+
+	pairingBox := schema.BoxForPairing(ob)
+	for _, draftID := range draftIDs {
+		paired, err := pairingBox.Query(schema.Pairing_.Draft.Equals(draftID)).Count()
+		if err != nil {
+			return err
+		}
+		if paired > 0 {
+			continue
+		}
+		draft, err := schema.BoxForDraft(ob).Get(draftID)
+		if err != nil {
+			return err
+		}
+		if draft == nil {
+			continue
+		}
+
+		seats := slices.Clone(draft.Seats)
+		slices.SortFunc(seats, func(a, b *schema.Seat) int {
+			return cmp.Compare(a.Position, b.Position)
+		})
+		var players []uint64
+		users := make(map[uint64]*schema.User)
+		for _, seat := range seats {
+			if seat.User != nil {
+				players = append(players, seat.User.Id)
+				users[seat.User.Id] = seat.User
+			}
+		}
+		results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draftID)).Find()
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(results, func(a, b *schema.Result) int {
+			return cmp.Compare(a.Id, b.Id)
+		})
+
+		for round := 1; round <= lastRounds[draftID]; round++ {
+			wins := make(map[uint64]int)
+			var reported []uint64
+			for _, result := range results {
+				if result.User == nil || result.Round >= round {
+					continue
+				}
+				if !slices.Contains(reported, result.User.Id) {
+					reported = append(reported, result.User.Id)
+				}
+				if result.Win {
+					wins[result.User.Id]++
+				}
+			}
+			matches, err := pairings.LegacyPair(round, players, wins, reported)
+			if err != nil {
+				log.Printf("can't rebuild round %d pairings for draft %d: %s", round, draftID, err.Error())
+				break
+			}
+			log.Printf("backfilling round %d pairings for draft %d", round, draftID)
+			for _, match := range matches {
+				_, err = pairingBox.Put(&schema.Pairing{
+					Draft:   draft,
+					Round:   round,
+					Player1: users[match.Player1],
+					Player2: users[match.Player2],
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// backfillResultGames gives results recorded before game scores were stored a 2-0 or 0-2 score.
+func backfillResultGames(ob *objectbox.ObjectBox) error {
+	resultBox := schema.BoxForResult(ob)
+	results, err := resultBox.Query(
+		schema.Result_.GamesWon.Equals(0),
+		schema.Result_.GamesLost.Equals(0),
+		schema.Result_.GamesDrawn.Equals(0)).Find()
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	log.Printf("backfilling game scores for %d results", len(results))
+	for _, result := range results {
+		if result.Win {
+			result.GamesWon = 2
+		} else {
+			result.GamesLost = 2
+		}
+	}
+	_, err = resultBox.PutMany(results)
+	return err
+}
+
+// backfillPairingResults confirms pairings whose two players reported results before pairings
+// stored them, as long as the two reports agree. Pairings with only one report, or with reports
+// that disagree, are left for the players to report again.
+func backfillPairingResults(ob *objectbox.ObjectBox) error {
+	pairingBox := schema.BoxForPairing(ob)
+	pending, err := pairingBox.Query(schema.Pairing_.Status.Equals("", true)).Find()
+	if err != nil {
+		return err
+	}
+	var confirmed []*schema.Pairing
+	for _, pairing := range pending {
+		if pairing.Draft == nil || pairing.Player1 == nil || pairing.Player2 == nil {
+			continue
+		}
+		result1, err := latestResult(ob, pairing.Draft.Id, pairing.Round, pairing.Player1.Id)
+		if err != nil {
+			return err
+		}
+		result2, err := latestResult(ob, pairing.Draft.Id, pairing.Round, pairing.Player2.Id)
+		if err != nil {
+			return err
+		}
+		if result1 == nil || result2 == nil ||
+			result1.GamesWon != result2.GamesLost ||
+			result1.GamesLost != result2.GamesWon ||
+			result1.GamesDrawn != result2.GamesDrawn {
+			continue
+		}
+		log.Printf("backfilling round %d result for draft %d", pairing.Round, pairing.Draft.Id)
+		// The main package's ResultConfirmed.
+		pairing.Status = "confirmed"
+		pairing.Wins1 = result1.GamesWon
+		pairing.Wins2 = result1.GamesLost
+		pairing.Draws = result1.GamesDrawn
+		confirmed = append(confirmed, pairing)
+	}
+	if len(confirmed) == 0 {
+		return nil
+	}
+	_, err = pairingBox.PutMany(confirmed)
+	return err
+}
+
+// latestResult returns the last result a user reported for a round of a draft, or nil if they
+// didn't report one.
+func latestResult(ob *objectbox.ObjectBox, draftID uint64, round int, userID uint64) (*schema.Result, error) {
+	results, err := schema.BoxForResult(ob).Query(
+		schema.Result_.Draft.Equals(draftID),
+		schema.Result_.Round.Equals(round),
+		schema.Result_.User.Equals(userID)).Find()
+	if err != nil {
+		return nil, err
+	}
+	var latest *schema.Result
+	for _, result := range results {
+		if latest == nil || result.Id > latest.Id {
+			latest = result
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/mtgolog"
+	"github.com/walkingeyerobot/r38/picklog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ServeAPIExportMtgoLog serves the /api/export/mtgolog endpoint, which writes a seat's picks as an MTGO
+// draft log. The seat query parameter picks a seat by position, defaulting to the user's own. Other
+// players' logs are only available once the draft is finished.
+func ServeAPIExportMtgoLog(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/export/mtgolog/(\d+)`)
+	if err != nil {
+		return err
+	}
+
+	var seatIndex int
+	if seatParam := r.URL.Query().Get("seat"); seatParam != "" {
+		position, err := strconv.Atoi(seatParam)
+		if err != nil {
+			return fmt.Errorf("bad seat: %w", err)
+		}
+		seatIndex = slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+			return seat.Position == position
+		})
+		if seatIndex == -1 {
+			return fmt.Errorf("no seat %d in draft %d", position, draft.Id)
+		}
+	} else {
+		seatIndex = slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+			return seat.User != nil && seat.User.Id == uint64(userID)
+		})
+		if seatIndex == -1 {
+			return fmt.Errorf("user %d isn't in draft %d", userID, draft.Id)
+		}
+	}
+	seat := draft.Seats[seatIndex]
+	if seat.User == nil || seat.User.Id != uint64(userID) {
+		err = checkDraftState(draft, "export someone else's draft log", lifecycle.State.Finished)
+		if err != nil {
+			return err
+		}
+	}
+
+	draftLog, err := makeMtgoLog(draft, seat)
+	if err != nil {
+		return fmt.Errorf("error building draft log for seat %d: %w", seat.Id, err)
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s - %s.txt"`,
+		safeFilename(draft.Name), safeFilename(draftLog.Players[draftLog.Self])))
+	return mtgolog.Write(w, draftLog)
+}
+
+// makeMtgoLog walks a draft's events to build one seat's draft log. In pick-two drafts each card
+// taken is its own pick, since MTGO logs mark one card per pick.
+func makeMtgoLog(draft *schema.Draft, seat *schema.Seat) (*mtgolog.Log, error) {
+	draftLog := &mtgolog.Log{
+		EventID: strconv.FormatUint(draft.Id, 10),
+	}
+	if isTimeSet(draft.StartedAt) {
+		draftLog.Time = draft.StartedAt.Format(mtgoLogTimeLayout)
+	}
+
+	seats := slices.Clone(draft.Seats)
+	slices.SortFunc(seats, func(a, b *schema.Seat) int {
+		return a.Position - b.Position
+	})
+	for i, s := range seats {
+		if s.Id == seat.Id {
+			draftLog.Self = i
+		}
+		switch {
+		case s.User == nil:
+			draftLog.Players = append(draftLog.Players, fmt.Sprintf("Seat %d", s.Position+1))
+		case s.User.MtgoName != "":
+			draftLog.Players = append(draftLog.Players, s.User.MtgoName)
+		default:
+			draftLog.Players = append(draftLog.Players, s.User.DiscordName)
+		}
+	}
+	for range 3 {
+		draftLog.Sets = append(draftLog.Sets, strings.ToUpper(draft.Format))
+	}
+
+	picksInRound := make(map[int]int)
+	var taken []uint64
+	for _, pick := range picklog.Reconstruct(draft) {
+		if pick.Seat == nil || pick.Seat.Id != seat.Id {
+			continue
+		}
+		picksInRound[pick.Round]++
+		logPick := mtgolog.Pick{
+			Pack:   pick.Round,
+			Number: picksInRound[pick.Round],
+			Picked: -1,
+		}
+		for _, card := range pick.Pack {
+			if slices.Contains(taken, card.Id) {
+				continue
+			}
+			name, err := picklog.CardName(card)
+			if err != nil {
+				return nil, err
+			}
+			if card.Id == pick.Card.Id {
+				logPick.Picked = len(logPick.Cards)
+			}
+			logPick.Cards = append(logPick.Cards, name)
+		}
+		if logPick.Picked == -1 {
+			return nil, fmt.Errorf("card %d wasn't in the pack it was picked from", pick.Card.Id)
+		}
+		taken = append(taken, pick.Card.Id)
+		draftLog.Picks = append(draftLog.Picks, logPick)
+	}
+	return draftLog, nil
+}
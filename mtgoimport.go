@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/draftconfig"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/mtgolog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// mtgoLogTimeLayout is how MTGO writes the time a draft started.
+const mtgoLogTimeLayout = "1/2/2006 3:04:05 PM"
+
+// ServeAPIImportMtgo serves the /api/import/mtgo endpoint. The body is an MTGO draft log, which becomes
+// a finished draft with the user in the seat that saved the log. The admin can import a log for someone
+// else with the user query parameter, and the name query parameter names the draft.
+func ServeAPIImportMtgo(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if r.Method != "POST" {
+		return MethodNotAllowedError
+	}
+
+	drafterID := userID
+	if userParam := r.URL.Query().Get("user"); userParam != "" {
+		if userID != 1 {
+			return fmt.Errorf("not allowed")
+		}
+		var err error
+		drafterID, err = strconv.ParseInt(userParam, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad user id: %w", err)
+		}
+	}
+	drafter, err := schema.BoxForUser(ob).Get(uint64(drafterID))
+	if err != nil {
+		return fmt.Errorf("error loading user %d: %w", drafterID, err)
+	}
+	if drafter == nil {
+		return fmt.Errorf("couldn't find user %d", drafterID)
+	}
+
+	draftLog, err := mtgolog.Parse(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading draft log: %w", err)
+	}
+	draft, err := importMtgoLog(ob, draftLog, drafter, r.URL.Query().Get("name"))
+	if err != nil {
+		return fmt.Errorf("error importing draft log: %w", err)
+	}
+
+	draftInfo, err := GetDraftListEntry(userID, ob, int64(draft.Id))
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(draftInfo)
+}
+
+// importMtgoLog turns an MTGO draft log into a finished draft. The drafter gets the seat that saved the
+// log, and everyone else gets a placeholder seat with no user. Each pack the drafter saw belongs to the
+// seat that opened it, with the cards in it the first time the drafter saw it; cards taken before that
+// are unknown.
+func importMtgoLog(ob *objectbox.ObjectBox, draftLog *mtgolog.Log, drafter *schema.User, name string) (*schema.Draft, error) {
+	cardData, err := loadCardDataByName()
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []string
+	for _, set := range draftLog.Sets {
+		set = strings.ToLower(set)
+		if !slices.Contains(sets, set) {
+			sets = append(sets, set)
+		}
+	}
+	format := strings.Join(sets, "-")
+	if format == "" {
+		format = "mtgo"
+	}
+	if name == "" {
+		name = fmt.Sprintf("MTGO draft %s", draftLog.EventID)
+	}
+
+	seats := make([]*schema.Seat, len(draftLog.Players))
+	for i := range seats {
+		seats[i] = &schema.Seat{
+			Position:      i,
+			Round:         4,
+			Packs:         []*schema.Pack{},
+			OriginalPacks: []*schema.Pack{},
+			PickedCards:   []*schema.Card{},
+		}
+	}
+	self := seats[draftLog.Self]
+	self.User = drafter
+
+	type packKey struct{ round, opener int }
+	packs := make(map[packKey]*schema.Pack)
+	cardNames := make(map[*schema.Card]string)
+	events := []*schema.Event{}
+	for i, pick := range draftLog.Picks {
+		key := packKey{pick.Pack, draftLog.Passer(pick)}
+		pack, ok := packs[key]
+		if !ok {
+			pack = &schema.Pack{
+				Round:         pick.Pack,
+				OriginalCards: []*schema.Card{},
+				Cards:         []*schema.Card{},
+			}
+			for _, cardName := range pick.Cards {
+				card := &schema.Card{Data: cardData(cardName)}
+				cardNames[card] = cardName
+				pack.OriginalCards = append(pack.OriginalCards, card)
+			}
+			packs[key] = pack
+			seats[key.opener].OriginalPacks = append(seats[key.opener].OriginalPacks, pack)
+		}
+
+		pickedIndex := slices.IndexFunc(pack.OriginalCards, func(card *schema.Card) bool {
+			return cardNames[card] == pick.PickedCard() && !slices.Contains(self.PickedCards, card)
+		})
+		if pickedIndex == -1 {
+			return nil, fmt.Errorf("%w: %s picked in pack %d pick %d wasn't in the pack the first time around",
+				mtgolog.ErrMalformed, pick.PickedCard(), pick.Pack, pick.Number)
+		}
+		picked := pack.OriginalCards[pickedIndex]
+		self.PickedCards = append(self.PickedCards, picked)
+		events = append(events, &schema.Event{
+			Position: draftLog.Self,
+			Card1:    picked,
+			Pack:     pack,
+			Modified: i + 1,
+			Round:    pick.Pack,
+		})
+	}
+
+	draft := &schema.Draft{
+		Name:            name,
+		Format:          format,
+		Seats:           seats,
+		UnassignedPacks: []*schema.Pack{},
+		Events:          events,
+		Waitlist:        []*schema.WaitlistEntry{},
+		State:           string(lifecycle.Complete),
+	}
+	if startedAt, err := time.Parse(mtgoLogTimeLayout, draftLog.Time); err == nil {
+		draft.StartedAt = startedAt
+	}
+	_, err = schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// loadCardDataByName indexes the card data in every set by card name, so imported cards look like
+// drafted ones. Cards that aren't in any set get just a name and a Scryfall image link.
+func loadCardDataByName() (func(string) string, error) {
+	foilStatus := regexp.MustCompile(`"FOIL_STATUS"`)
+	byName := make(map[string]string)
+	for _, format := range formats() {
+		setBytes, err := os.ReadFile("sets/" + format + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("error reading set %s: %w", format, err)
+		}
+		var set struct {
+			Cards []draftconfig.Card `json:"cards"`
+		}
+		err = json.Unmarshal(setBytes, &set)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing set %s: %w", format, err)
+		}
+		for _, card := range set.Cards {
+			var data draftconfig.CardData
+			cardJSON := foilStatus.ReplaceAllString(card.Data, "false")
+			if json.Unmarshal([]byte(cardJSON), &data) != nil {
+				continue
+			}
+			if _, ok := byName[data.Scryfall.Name]; !ok {
+				byName[data.Scryfall.Name] = cardJSON
+			}
+		}
+	}
+
+	return func(name string) string {
+		if data, ok := byName[name]; ok {
+			return data
+		}
+		data, _ := json.Marshal(draftconfig.CardData{
+			Scryfall: draftconfig.CardScryfallData{
+				Name:          name,
+				Colors:        []string{},
+				ColorIdentity: []string{},
+			},
+			ImageUris: []string{"https://api.scryfall.com/cards/named?format=image&exact=" + url.QueryEscape(name)},
+		})
+		return string(data)
+	}, nil
+}
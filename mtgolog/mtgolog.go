@@ -0,0 +1,198 @@
+// Package mtgolog reads and writes the draft logs MTGO saves after a draft.
+//
+// A log looks like this, with "-->" marking the player who saved it and each card they picked:
+//
+//	Event #: 3141592
+//	Time:    10/2/2021 8:12:34 PM
+//	Players:
+//	    Alice
+//	--> Bob
+//
+//	------ MID ------
+//
+//	Pack 1 pick 1:
+//	    Augur of Autumn
+//	--> Brutal Cathar
+package mtgolog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrMalformed is returned when a log can't be parsed.
+var ErrMalformed = errors.New("malformed MTGO draft log")
+
+const (
+	markedPrefix   = "--> "
+	unmarkedPrefix = "    "
+)
+
+// Log is one player's view of an MTGO draft.
+type Log struct {
+	EventID string
+	Time    string
+	Players []string
+	// Self is the index in Players of the player who saved the log.
+	Self  int
+	Sets  []string
+	Picks []Pick
+}
+
+// Pick is the pack a player was shown and the card they took from it.
+type Pick struct {
+	// Pack is the round, starting at 1.
+	Pack int
+	// Number is the pick within the round, starting at 1.
+	Number int
+	Cards  []string
+	// Picked is the index in Cards of the card that was taken.
+	Picked int
+}
+
+var (
+	setPattern  = regexp.MustCompile(`^------ (\S+) ------$`)
+	pickPattern = regexp.MustCompile(`^Pack (\d+) pick (\d+):$`)
+)
+
+// Parse reads a draft log.
+func Parse(r io.Reader) (*Log, error) {
+	log := &Log{Self: -1}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	inPlayers := false
+	var pick *Pick
+	finishPick := func() error {
+		if pick == nil {
+			return nil
+		}
+		if pick.Picked == -1 {
+			return fmt.Errorf("%w: no card picked in pack %d pick %d", ErrMalformed, pick.Pack, pick.Number)
+		}
+		log.Picks = append(log.Picks, *pick)
+		pick = nil
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\ufeff"), " \t\r")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			inPlayers = false
+			err := finishPick()
+			if err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "Event #:"):
+			log.EventID = strings.TrimSpace(strings.TrimPrefix(line, "Event #:"))
+		case strings.HasPrefix(line, "Time:"):
+			log.Time = strings.TrimSpace(strings.TrimPrefix(line, "Time:"))
+		case line == "Players:":
+			inPlayers = true
+		case setPattern.MatchString(trimmed):
+			log.Sets = append(log.Sets, setPattern.FindStringSubmatch(trimmed)[1])
+		case pickPattern.MatchString(line):
+			err := finishPick()
+			if err != nil {
+				return nil, err
+			}
+			match := pickPattern.FindStringSubmatch(line)
+			pack, _ := strconv.Atoi(match[1])
+			number, _ := strconv.Atoi(match[2])
+			pick = &Pick{Pack: pack, Number: number, Picked: -1}
+		case strings.HasPrefix(line, markedPrefix) || strings.HasPrefix(line, unmarkedPrefix):
+			marked := strings.HasPrefix(line, markedPrefix)
+			name := strings.TrimSpace(line[len(markedPrefix):])
+			if inPlayers {
+				if marked {
+					log.Self = len(log.Players)
+				}
+				log.Players = append(log.Players, name)
+			} else if pick != nil {
+				if marked {
+					if pick.Picked != -1 {
+						return nil, fmt.Errorf("%w: line %d: second pick in pack %d pick %d",
+							ErrMalformed, lineNumber, pick.Pack, pick.Number)
+					}
+					pick.Picked = len(pick.Cards)
+				}
+				pick.Cards = append(pick.Cards, name)
+			} else {
+				return nil, fmt.Errorf("%w: line %d: card outside of a pick", ErrMalformed, lineNumber)
+			}
+		default:
+			return nil, fmt.Errorf("%w: line %d: unexpected %q", ErrMalformed, lineNumber, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	err := finishPick()
+	if err != nil {
+		return nil, err
+	}
+
+	if log.Self == -1 {
+		return nil, fmt.Errorf("%w: no player is marked as the log's owner", ErrMalformed)
+	}
+	if len(log.Picks) == 0 {
+		return nil, fmt.Errorf("%w: no picks", ErrMalformed)
+	}
+	return log, nil
+}
+
+// PickedCard is the name of the card taken in a pick.
+func (p Pick) PickedCard() string {
+	return p.Cards[p.Picked]
+}
+
+// Passer returns the index in Players of the player whose pack was shown to the log's owner at a pick.
+// Packs 1 and 3 pass left, to the next player in the list, and pack 2 passes right.
+func (l *Log) Passer(pick Pick) int {
+	n := len(l.Players)
+	offset := (pick.Number - 1) % n
+	if pick.Pack%2 == 0 {
+		return (l.Self + offset) % n
+	}
+	return ((l.Self-offset)%n + n) % n
+}
+
+// Write writes a draft log in the same format MTGO saves them in.
+func Write(w io.Writer, log *Log) error {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "Event #: %s\n", log.EventID)
+	_, _ = fmt.Fprintf(&sb, "Time:    %s\n", log.Time)
+	sb.WriteString("Players:\n")
+	for i, player := range log.Players {
+		if i == log.Self {
+			sb.WriteString(markedPrefix + player + "\n")
+		} else {
+			sb.WriteString(unmarkedPrefix + player + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	for _, set := range log.Sets {
+		_, _ = fmt.Fprintf(&sb, "------ %s ------ \n\n", set)
+	}
+	for _, pick := range log.Picks {
+		_, _ = fmt.Fprintf(&sb, "Pack %d pick %d:\n", pick.Pack, pick.Number)
+		for i, card := range pick.Cards {
+			if i == pick.Picked {
+				sb.WriteString(markedPrefix + card + "\n")
+			} else {
+				sb.WriteString(unmarkedPrefix + card + "\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,113 @@
+package mtgolog
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testLog = `Event #: 3141592
+Time:    10/2/2021 8:12:34 PM
+Players:
+    Alice
+--> Bob
+    Carol
+
+------ MID ------
+
+------ MID ------
+
+------ MID ------
+
+Pack 1 pick 1:
+    Augur of Autumn
+--> Brutal Cathar
+    Consider
+
+Pack 1 pick 2:
+--> Play with Fire
+    Duel for Dominance
+
+Pack 2 pick 1:
+    Thermo-Alchemist
+--> Fateful Absence
+
+Pack 2 pick 2:
+--> Jadar, Ghoulcaller of Nephalia
+
+`
+
+func TestParse(t *testing.T) {
+	log, err := Parse(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.EventID != "3141592" {
+		t.Errorf("expected event 3141592, got %s", log.EventID)
+	}
+	if len(log.Players) != 3 || log.Self != 1 || log.Players[log.Self] != "Bob" {
+		t.Errorf("expected Bob to own the log, got %v (%d)", log.Players, log.Self)
+	}
+	if len(log.Sets) != 3 || log.Sets[0] != "MID" {
+		t.Errorf("expected three MID packs, got %v", log.Sets)
+	}
+	if len(log.Picks) != 4 {
+		t.Fatalf("expected 4 picks, got %d", len(log.Picks))
+	}
+	first := log.Picks[0]
+	if first.Pack != 1 || first.Number != 1 || len(first.Cards) != 3 || first.PickedCard() != "Brutal Cathar" {
+		t.Errorf("first pick parsed wrong: %+v", first)
+	}
+	if log.Picks[3].PickedCard() != "Jadar, Ghoulcaller of Nephalia" {
+		t.Errorf("last pick parsed wrong: %+v", log.Picks[3])
+	}
+}
+
+func TestParseRejectsPickWithoutMarkedCard(t *testing.T) {
+	_, err := Parse(strings.NewReader(`Players:
+--> Bob
+
+Pack 1 pick 1:
+    Consider
+`))
+	if !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+}
+
+func TestPasser(t *testing.T) {
+	log, err := Parse(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bob is between Alice and Carol. Pack 1 passes left, so Bob's second pack comes from Alice.
+	if passer := log.Passer(log.Picks[1]); passer != 0 {
+		t.Errorf("expected pack 1 pick 2 to come from Alice, got %s", log.Players[passer])
+	}
+	if passer := log.Passer(log.Picks[3]); passer != 2 {
+		t.Errorf("expected pack 2 pick 2 to come from Carol, got %s", log.Players[passer])
+	}
+	if passer := log.Passer(log.Picks[0]); passer != 1 {
+		t.Errorf("expected first pick to be from Bob's own pack, got %s", log.Players[passer])
+	}
+}
+
+func TestWriteRoundTrips(t *testing.T) {
+	log, err := Parse(strings.NewReader(testLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	err = Write(&sb, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reparsed, err := Parse(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("couldn't parse written log: %s\n%s", err, sb.String())
+	}
+	if !reflect.DeepEqual(log, reparsed) {
+		t.Errorf("expected %+v, got %+v", log, reparsed)
+	}
+}
@@ -0,0 +1,77 @@
+package pairings
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotLegacyRound is returned by LegacyPair for rounds the fixed bracket didn't pair.
+var ErrNotLegacyRound = errors.New("round wasn't paired by the fixed bracket")
+
+// LegacyPair replays the fixed bracket drafts were paired with before Swiss pairings, so their pairings
+// can be rebuilt from the results they recorded. players is the seating order. Round 1 pairs players
+// across the table. Eight-player drafts then paired players on the same record: in round 2, the winners
+// and the losers were each split between even and odd seats, and in round 3 the 1-1 players were split
+// between the two halves of the table in the order their results were first reported.
+//
+// wins is each player's match wins before the round, and reported lists players in the order their
+// first result was reported.
+func LegacyPair(round int, players []uint64, wins map[uint64]int, reported []uint64) ([]Match, error) {
+	if round == 1 && len(players) >= 2 && len(players)%2 == 0 {
+		half := len(players) / 2
+		var matches []Match
+		for i := range half {
+			matches = append(matches, Match{Round: 1, Player1: players[i], Player2: players[i+half]})
+		}
+		return matches, nil
+	}
+	if len(players) != 8 || (round != 2 && round != 3) {
+		return nil, fmt.Errorf("%w: round %d with %d players", ErrNotLegacyRound, round, len(players))
+	}
+
+	seats := make(map[uint64]int)
+	for seat, player := range players {
+		seats[player] = seat
+	}
+	tables := make([][]uint64, 4)
+	for _, player := range reported {
+		seat, ok := seats[player]
+		if !ok {
+			continue
+		}
+		var table int
+		if round == 2 {
+			if wins[player] == 0 {
+				table = 2
+			}
+			table += seat % 2
+		} else {
+			switch {
+			case wins[player] == 2:
+				table = 0
+			case wins[player] == 0:
+				table = 3
+			case seat < 4:
+				table = 1
+				if len(tables[1]) == 2 {
+					table = 2
+				}
+			default:
+				table = 2
+				if len(tables[2]) == 2 {
+					table = 1
+				}
+			}
+		}
+		tables[table] = append(tables[table], player)
+	}
+
+	var matches []Match
+	for _, table := range tables {
+		if len(table) != 2 {
+			return nil, fmt.Errorf("%w: round %d has a table of %d players", ErrNotLegacyRound, round, len(table))
+		}
+		matches = append(matches, Match{Round: round, Player1: table[0], Player2: table[1]})
+	}
+	return matches, nil
+}
@@ -0,0 +1,310 @@
+// Package pairings runs Swiss tournaments: it pairs each round, hands out byes, and ranks players with
+// the usual tiebreakers (opponents' match-win percentage, game-win percentage and opponents' game-win
+// percentage).
+//
+// Players are identified by nonzero IDs. Nothing here knows about drafts or Discord.
+package pairings
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// Bye is the opponent of a player who has a bye.
+const Bye uint64 = 0
+
+var (
+	// ErrTooFewPlayers is returned when there aren't enough players to pair a round.
+	ErrTooFewPlayers = errors.New("at least two players are needed for pairings")
+	// ErrRoundInProgress is returned when asked to pair a round before the last one is reported.
+	ErrRoundInProgress = errors.New("the current round hasn't finished")
+)
+
+// Match is one pairing in a round and, once it's been played, its result.
+type Match struct {
+	Round   int
+	Player1 uint64
+	// Player2 is Bye if Player1 has a bye.
+	Player2 uint64
+	// Wins1 and Wins2 are the games won by each player.
+	Wins1    int
+	Wins2    int
+	Draws    int
+	Reported bool
+}
+
+// NewBye is a reported match giving player a bye, which counts as a 2-0 win.
+func NewBye(round int, player uint64) Match {
+	return Match{Round: round, Player1: player, Player2: Bye, Wins1: 2, Reported: true}
+}
+
+// IsBye reports whether the match is a bye.
+func (m Match) IsBye() bool {
+	return m.Player2 == Bye
+}
+
+// Has reports whether player is in the match.
+func (m Match) Has(player uint64) bool {
+	return m.Player1 == player || m.Player2 == player
+}
+
+// DefaultRounds is how many rounds it takes for a single player to finish undefeated.
+func DefaultRounds(players int) int {
+	if players < 2 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(players))))
+}
+
+// LastRound is the highest round in matches, or 0 if there are none.
+func LastRound(matches []Match) int {
+	round := 0
+	for _, match := range matches {
+		round = max(round, match.Round)
+	}
+	return round
+}
+
+// RoundFinished reports whether round has been paired and every match in it reported.
+func RoundFinished(matches []Match, round int) bool {
+	paired := false
+	for _, match := range matches {
+		if match.Round != round {
+			continue
+		}
+		if !match.Reported {
+			return false
+		}
+		paired = true
+	}
+	return paired
+}
+
+// Pair pairs the round after the last one in matches. players is the seating order, which is used to
+// pair the first round across the table and to break ties that nothing else breaks.
+//
+// Later rounds pair players with the same record where possible and never pair a rematch unless there's
+// no other way to pair the round. With an odd number of players, the lowest-ranked player who hasn't
+// had a bye gets one.
+func Pair(players []uint64, matches []Match) ([]Match, error) {
+	if len(players) < 2 {
+		return nil, ErrTooFewPlayers
+	}
+	last := LastRound(matches)
+	if last > 0 && !RoundFinished(matches, last) {
+		return nil, ErrRoundInProgress
+	}
+	round := last + 1
+
+	if round == 1 {
+		return pairFirstRound(players), nil
+	}
+
+	standings := Standings(players, matches)
+	ranked := make([]uint64, len(standings))
+	byes := make(map[uint64]int)
+	for i, standing := range standings {
+		ranked[i] = standing.Player
+		byes[standing.Player] = standing.Byes
+	}
+	played := make(map[[2]uint64]bool)
+	for _, match := range matches {
+		played[matchKey(match.Player1, match.Player2)] = true
+	}
+
+	// Byes go to the lowest-ranked player with the fewest byes, moving up the standings if that
+	// player can't get a bye without forcing a rematch.
+	byeCandidates := []uint64{Bye}
+	if len(ranked)%2 == 1 {
+		byeCandidates = slices.Clone(ranked)
+		slices.Reverse(byeCandidates)
+		slices.SortStableFunc(byeCandidates, func(a, b uint64) int {
+			return cmp.Compare(byes[a], byes[b])
+		})
+	}
+	for _, candidate := range byeCandidates {
+		remaining := slices.DeleteFunc(slices.Clone(ranked), func(player uint64) bool {
+			return player == candidate
+		})
+		pairs, ok := pairWithoutRematches(remaining, played)
+		if ok {
+			return makeRound(round, pairs, candidate), nil
+		}
+	}
+
+	// Every pairing needs a rematch, so pair straight down the standings.
+	bye := byeCandidates[0]
+	remaining := slices.DeleteFunc(slices.Clone(ranked), func(player uint64) bool {
+		return player == bye
+	})
+	var pairs [][2]uint64
+	for i := 0; i+1 < len(remaining); i += 2 {
+		pairs = append(pairs, [2]uint64{remaining[i], remaining[i+1]})
+	}
+	return makeRound(round, pairs, bye), nil
+}
+
+// pairFirstRound pairs each player with the one across the table. With an odd number of players, the
+// last one gets a bye.
+func pairFirstRound(players []uint64) []Match {
+	bye := Bye
+	if len(players)%2 == 1 {
+		bye = players[len(players)-1]
+		players = players[:len(players)-1]
+	}
+	half := len(players) / 2
+	var pairs [][2]uint64
+	for i := range half {
+		pairs = append(pairs, [2]uint64{players[i], players[i+half]})
+	}
+	return makeRound(1, pairs, bye)
+}
+
+func makeRound(round int, pairs [][2]uint64, bye uint64) []Match {
+	var matches []Match
+	for _, pair := range pairs {
+		matches = append(matches, Match{Round: round, Player1: pair[0], Player2: pair[1]})
+	}
+	if bye != Bye {
+		matches = append(matches, NewBye(round, bye))
+	}
+	return matches
+}
+
+// pairWithoutRematches pairs the highest-ranked player with the next-highest one they haven't played,
+// backtracking when that leaves the rest of the players impossible to pair.
+func pairWithoutRematches(ranked []uint64, played map[[2]uint64]bool) ([][2]uint64, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+	first := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		if played[matchKey(first, ranked[i])] {
+			continue
+		}
+		rest := make([]uint64, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+		pairs, ok := pairWithoutRematches(rest, played)
+		if ok {
+			return append([][2]uint64{{first, ranked[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+func matchKey(a, b uint64) [2]uint64 {
+	return [2]uint64{min(a, b), max(a, b)}
+}
+
+// Standing is a player's record and tiebreakers.
+type Standing struct {
+	Player      uint64
+	Points      int
+	MatchWins   int
+	MatchLosses int
+	MatchDraws  int
+	GameWins    int
+	GameLosses  int
+	GameDraws   int
+	Byes        int
+	// Opponents doesn't include byes.
+	Opponents []uint64
+	// MatchWinPct, GameWinPct, OpponentMatchWinPct and OpponentGameWinPct are between 0 and 1. A
+	// player's own percentages are never counted as lower than 1/3 when working out their opponents'
+	// tiebreakers.
+	MatchWinPct         float64
+	GameWinPct          float64
+	OpponentMatchWinPct float64
+	OpponentGameWinPct  float64
+}
+
+// Points awarded for each match result.
+const (
+	WinPoints  = 3
+	DrawPoints = 1
+)
+
+const minimumPct = 1.0 / 3
+
+// Standings ranks players by match points, then OMW%, GW% and OGW%, and then by their order in players.
+// Only reported matches count.
+func Standings(players []uint64, matches []Match) []Standing {
+	standings := make(map[uint64]*Standing, len(players))
+	for _, player := range players {
+		standings[player] = &Standing{Player: player}
+	}
+	record := func(player, opponent uint64, wins, losses, draws int) {
+		standing, ok := standings[player]
+		if !ok {
+			return
+		}
+		standing.GameWins += wins
+		standing.GameLosses += losses
+		standing.GameDraws += draws
+		switch {
+		case wins > losses:
+			standing.MatchWins++
+		case wins < losses:
+			standing.MatchLosses++
+		default:
+			standing.MatchDraws++
+		}
+		if opponent == Bye {
+			standing.Byes++
+		} else {
+			standing.Opponents = append(standing.Opponents, opponent)
+		}
+	}
+	for _, match := range matches {
+		if !match.Reported {
+			continue
+		}
+		record(match.Player1, match.Player2, match.Wins1, match.Wins2, match.Draws)
+		if !match.IsBye() {
+			record(match.Player2, match.Player1, match.Wins2, match.Wins1, match.Draws)
+		}
+	}
+
+	for _, standing := range standings {
+		standing.Points = WinPoints*standing.MatchWins + DrawPoints*standing.MatchDraws
+		played := standing.MatchWins + standing.MatchLosses + standing.MatchDraws
+		if played > 0 {
+			standing.MatchWinPct = float64(standing.Points) / float64(WinPoints*played)
+		}
+		games := standing.GameWins + standing.GameLosses + standing.GameDraws
+		if games > 0 {
+			gamePoints := WinPoints*standing.GameWins + DrawPoints*standing.GameDraws
+			standing.GameWinPct = float64(gamePoints) / float64(WinPoints*games)
+		}
+	}
+	for _, standing := range standings {
+		if len(standing.Opponents) == 0 {
+			continue
+		}
+		for _, opponent := range standing.Opponents {
+			if opponentStanding, ok := standings[opponent]; ok {
+				standing.OpponentMatchWinPct += max(minimumPct, opponentStanding.MatchWinPct)
+				standing.OpponentGameWinPct += max(minimumPct, opponentStanding.GameWinPct)
+			}
+		}
+		standing.OpponentMatchWinPct /= float64(len(standing.Opponents))
+		standing.OpponentGameWinPct /= float64(len(standing.Opponents))
+	}
+
+	ranked := make([]Standing, len(players))
+	for i, player := range players {
+		ranked[i] = *standings[player]
+	}
+	slices.SortStableFunc(ranked, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(b.OpponentMatchWinPct, a.OpponentMatchWinPct),
+			cmp.Compare(b.GameWinPct, a.GameWinPct),
+			cmp.Compare(b.OpponentGameWinPct, a.OpponentGameWinPct),
+		)
+	})
+	return ranked
+}
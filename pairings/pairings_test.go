@@ -0,0 +1,253 @@
+package pairings
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// report fills in results for every unreported match, with the lower-numbered player winning 2-1.
+func report(matches []Match) {
+	for i := range matches {
+		if matches[i].Reported {
+			continue
+		}
+		if matches[i].Player1 < matches[i].Player2 {
+			matches[i].Wins1, matches[i].Wins2 = 2, 1
+		} else {
+			matches[i].Wins1, matches[i].Wins2 = 1, 2
+		}
+		matches[i].Reported = true
+	}
+}
+
+func playTournament(t *testing.T, players []uint64, rounds int) []Match {
+	var matches []Match
+	for round := 1; round <= rounds; round++ {
+		next, err := Pair(players, matches)
+		if err != nil {
+			t.Fatalf("error pairing round %d: %s", round, err)
+		}
+		seen := make(map[uint64]bool)
+		for _, match := range next {
+			if match.Round != round {
+				t.Errorf("expected round %d, got %+v", round, match)
+			}
+			for _, player := range []uint64{match.Player1, match.Player2} {
+				if player == Bye {
+					continue
+				}
+				if seen[player] {
+					t.Errorf("player %d paired twice in round %d", player, round)
+				}
+				seen[player] = true
+			}
+		}
+		if len(seen) != len(players) {
+			t.Errorf("expected all %d players to be paired in round %d, got %d", len(players), round, len(seen))
+		}
+		report(next)
+		matches = append(matches, next...)
+	}
+	return matches
+}
+
+func TestFirstRoundPairsAcrossTheTable(t *testing.T) {
+	matches, err := Pair([]uint64{1, 2, 3, 4, 5, 6, 7, 8}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][2]uint64{{1, 5}, {2, 6}, {3, 7}, {4, 8}}
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %+v", len(expected), matches)
+	}
+	for i, match := range matches {
+		if match.Player1 != expected[i][0] || match.Player2 != expected[i][1] || match.Round != 1 {
+			t.Errorf("expected %v, got %+v", expected[i], match)
+		}
+	}
+}
+
+func TestOddPlayerCountsGetByes(t *testing.T) {
+	players := []uint64{1, 2, 3, 4, 5}
+	matches := playTournament(t, players, 4)
+	byes := make(map[uint64]int)
+	for _, match := range matches {
+		if match.IsBye() {
+			byes[match.Player1]++
+			if !match.Reported || match.Wins1 != 2 {
+				t.Errorf("expected bye to be a reported 2-0 win, got %+v", match)
+			}
+		}
+	}
+	if len(byes) != 4 {
+		t.Errorf("expected four different players to get byes, got %v", byes)
+	}
+	for player, count := range byes {
+		if count > 1 {
+			t.Errorf("player %d got %d byes", player, count)
+		}
+	}
+}
+
+func TestNoRematches(t *testing.T) {
+	for _, players := range [][]uint64{
+		{1, 2, 3, 4, 5, 6, 7, 8},
+		{1, 2, 3, 4, 5, 6},
+		{1, 2, 3, 4, 5, 6, 7},
+		{8, 3, 5, 1, 2, 7, 4, 6, 9, 10},
+	} {
+		matches := playTournament(t, players, DefaultRounds(len(players))+1)
+		played := make(map[[2]uint64]bool)
+		for _, match := range matches {
+			if match.IsBye() {
+				continue
+			}
+			key := matchKey(match.Player1, match.Player2)
+			if played[key] {
+				t.Errorf("%d players: rematch between %d and %d in round %d",
+					len(players), match.Player1, match.Player2, match.Round)
+			}
+			played[key] = true
+		}
+	}
+}
+
+func TestLaterRoundsPairPlayersWithTheSameRecord(t *testing.T) {
+	players := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	matches := playTournament(t, players, 3)
+	standings := Standings(players, matches)
+	if standings[0].Player != 1 || standings[0].MatchWins != 3 {
+		t.Errorf("expected player 1 to finish 3-0, got %+v", standings[0])
+	}
+	if standings[len(standings)-1].MatchLosses != 3 {
+		t.Errorf("expected someone to finish 0-3, got %+v", standings[len(standings)-1])
+	}
+}
+
+func TestPairRejectsUnfinishedRound(t *testing.T) {
+	players := []uint64{1, 2, 3, 4}
+	matches, err := Pair(players, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Pair(players, matches)
+	if !errors.Is(err, ErrRoundInProgress) {
+		t.Errorf("expected ErrRoundInProgress, got %v", err)
+	}
+	_, err = Pair(players[:1], nil)
+	if !errors.Is(err, ErrTooFewPlayers) {
+		t.Errorf("expected ErrTooFewPlayers, got %v", err)
+	}
+}
+
+func TestStandingsTiebreakers(t *testing.T) {
+	players := []uint64{1, 2, 3, 4}
+	matches := []Match{
+		{Round: 1, Player1: 1, Player2: 3, Wins1: 2, Wins2: 0, Reported: true},
+		{Round: 1, Player1: 2, Player2: 4, Wins1: 2, Wins2: 1, Reported: true},
+		{Round: 2, Player1: 1, Player2: 2, Wins1: 1, Wins2: 2, Reported: true},
+		{Round: 2, Player1: 3, Player2: 4, Wins1: 2, Wins2: 1, Draws: 1, Reported: true},
+	}
+	standings := Standings(players, matches)
+	order := []uint64{2, 1, 3, 4}
+	for i, player := range order {
+		if standings[i].Player != player {
+			t.Fatalf("expected order %v, got %+v", order, standings)
+		}
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	// Player 1 beat player 3 2-0 and lost to player 2 1-2.
+	one := standings[1]
+	if one.Points != 3 || one.GameWins != 3 || one.GameLosses != 2 {
+		t.Errorf("wrong record for player 1: %+v", one)
+	}
+	if !near(one.GameWinPct, 9.0/15) {
+		t.Errorf("expected GW%% of 0.6, got %f", one.GameWinPct)
+	}
+	if !near(one.OpponentMatchWinPct, (0.5+1.0)/2) {
+		t.Errorf("expected OMW%% of 0.75, got %f", one.OpponentMatchWinPct)
+	}
+	// Player 4 lost both matches. Their own MW% stays 0, but it counts as a third for player 2.
+	if standings[3].MatchWinPct != 0 {
+		t.Errorf("expected player 4's own MW%% to be 0, got %f", standings[3].MatchWinPct)
+	}
+	if !near(standings[0].OpponentMatchWinPct, (1.0/3+0.5)/2) {
+		t.Errorf("expected player 2's OMW%% to use the floor, got %f", standings[0].OpponentMatchWinPct)
+	}
+	// Player 3 lost 0-2, then won 2-1-1: 7 game points out of 18.
+	if !near(standings[2].GameWinPct, 7.0/18) {
+		t.Errorf("expected GW%% of 7/18, got %f", standings[2].GameWinPct)
+	}
+}
+
+func TestByesDontCountAsOpponents(t *testing.T) {
+	players := []uint64{1, 2, 3}
+	matches := []Match{
+		{Round: 1, Player1: 1, Player2: 2, Wins1: 2, Reported: true},
+		NewBye(1, 3),
+	}
+	standings := Standings(players, matches)
+	for _, standing := range standings {
+		if standing.Player != 3 {
+			continue
+		}
+		if standing.Points != WinPoints || standing.Byes != 1 || len(standing.Opponents) != 0 ||
+			standing.OpponentMatchWinPct != 0 {
+			t.Errorf("expected bye to be a win with no opponent, got %+v", standing)
+		}
+	}
+}
+
+func TestDefaultRounds(t *testing.T) {
+	for players, rounds := range map[int]int{2: 1, 4: 2, 5: 3, 8: 3, 9: 4} {
+		if got := DefaultRounds(players); got != rounds {
+			t.Errorf("expected %d rounds for %d players, got %d", rounds, players, got)
+		}
+	}
+}
+
+func TestLegacyPairReplaysFixedBracket(t *testing.T) {
+	players := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	reported := players
+	for _, tc := range []struct {
+		round    int
+		wins     map[uint64]int
+		expected [][2]uint64
+	}{
+		{1, nil, [][2]uint64{{1, 5}, {2, 6}, {3, 7}, {4, 8}}},
+		{2, map[uint64]int{1: 1, 2: 1, 3: 1, 4: 1}, [][2]uint64{{1, 3}, {2, 4}, {5, 7}, {6, 8}}},
+		// Three of the 1-1 players sat in the first half of the table, so one of them moves down a table.
+		{3, map[uint64]int{1: 1, 2: 1, 3: 1, 4: 2, 5: 1, 6: 2}, [][2]uint64{{4, 6}, {1, 2}, {3, 5}, {7, 8}}},
+	} {
+		matches, err := LegacyPair(tc.round, players, tc.wins, reported)
+		if err != nil {
+			t.Fatalf("error replaying round %d: %s", tc.round, err)
+		}
+		if len(matches) != len(tc.expected) {
+			t.Fatalf("expected %d matches in round %d, got %+v", len(tc.expected), tc.round, matches)
+		}
+		for i, match := range matches {
+			if match.Round != tc.round || match.Player1 != tc.expected[i][0] || match.Player2 != tc.expected[i][1] {
+				t.Errorf("expected %v in round %d, got %+v", tc.expected[i], tc.round, match)
+			}
+		}
+	}
+}
+
+func TestLegacyPairRejectsRoundsOutsideTheBracket(t *testing.T) {
+	for _, tc := range []struct {
+		round   int
+		players []uint64
+	}{
+		{2, []uint64{1, 2, 3, 4}},
+		{4, []uint64{1, 2, 3, 4, 5, 6, 7, 8}},
+		{1, []uint64{1, 2, 3}},
+	} {
+		_, err := LegacyPair(tc.round, tc.players, map[uint64]int{}, tc.players)
+		if !errors.Is(err, ErrNotLegacyRound) {
+			t.Errorf("expected ErrNotLegacyRound for round %d with %d players, got %v", tc.round, len(tc.players), err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Package picklog reconstructs every pick in a draft, including what was in the pack at the time,
+// and writes them out as datasets for analysis.
+package picklog
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// Pick is one card taken from a pack.
+type Pick struct {
+	Seat *schema.Seat
+	// Round is the pack number, starting at 1.
+	Round int
+	// Number counts the seat's picks within the round, starting at 1. Both cards taken in one pick
+	// of a pick-two draft share a number.
+	Number int
+	Card   *schema.Card
+	// Pack is everything in the pack when the pick was made, including Card.
+	Pack []*schema.Card
+}
+
+// Reconstruct replays a draft's events in order, working out what was left in each pack at every pick.
+func Reconstruct(draft *schema.Draft) []Pick {
+	events := slices.Clone(draft.Events)
+	slices.SortFunc(events, func(a, b *schema.Event) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	seats := make(map[int]*schema.Seat)
+	for _, seat := range draft.Seats {
+		seats[seat.Position] = seat
+	}
+
+	taken := make(map[uint64][]uint64)
+	type seatRound struct{ position, round int }
+	pickCounts := make(map[seatRound]int)
+	var picks []Pick
+	for _, event := range events {
+		if event.Pack == nil || event.Card1 == nil {
+			continue
+		}
+		pack := event.Pack
+		var contents []*schema.Card
+		for _, card := range pack.OriginalCards {
+			if !slices.Contains(taken[pack.Id], card.Id) {
+				contents = append(contents, card)
+			}
+		}
+
+		key := seatRound{event.Position, event.Round}
+		pickCounts[key]++
+		for _, card := range []*schema.Card{event.Card1, event.Card2} {
+			if card == nil {
+				continue
+			}
+			picks = append(picks, Pick{
+				Seat:   seats[event.Position],
+				Round:  event.Round,
+				Number: pickCounts[key],
+				Card:   card,
+				Pack:   contents,
+			})
+			taken[pack.Id] = append(taken[pack.Id], card.Id)
+		}
+	}
+	return picks
+}
+
+// Record is a drafter's match results in a draft.
+type Record struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+// LoadRecords tallies each user's match results in a draft. Byes aren't matches, so they're left out.
+func LoadRecords(ob *objectbox.ObjectBox, draftID uint64) (map[uint64]Record, error) {
+	results, err := schema.BoxForResult(ob).Query(schema.Result_.Draft.Equals(draftID)).Find()
+	if err != nil {
+		return nil, err
+	}
+	pairings, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draftID)).Find()
+	if err != nil {
+		return nil, err
+	}
+	type userRound struct {
+		user  uint64
+		round int
+	}
+	byes := make(map[userRound]bool)
+	for _, pairing := range pairings {
+		if pairing.Player2 == nil && pairing.Player1 != nil {
+			byes[userRound{pairing.Player1.Id, pairing.Round}] = true
+		}
+	}
+	records := make(map[uint64]Record)
+	for _, result := range results {
+		if result.User == nil || byes[userRound{result.User.Id, result.Round}] {
+			continue
+		}
+		record := records[result.User.Id]
+		switch {
+		case result.GamesWon > result.GamesLost:
+			record.Wins++
+		case result.GamesWon < result.GamesLost:
+			record.Losses++
+		default:
+			record.Draws++
+		}
+		records[result.User.Id] = record
+	}
+	return records, nil
+}
+
+// Row is one pick in the dataset.
+type Row struct {
+	DraftID    uint64   `json:"draftId"`
+	Format     string   `json:"format"`
+	Seat       int      `json:"seat"`
+	Round      int      `json:"round"`
+	PickNumber int      `json:"pickNumber"`
+	Card       string   `json:"card"`
+	Pack       []string `json:"pack"`
+	Record     Record   `json:"record"`
+}
+
+// Rows turns a draft's picks into dataset rows.
+func Rows(draft *schema.Draft, records map[uint64]Record) ([]Row, error) {
+	var rows []Row
+	for _, pick := range Reconstruct(draft) {
+		row := Row{
+			DraftID:    draft.Id,
+			Format:     draft.Format,
+			Round:      pick.Round,
+			PickNumber: pick.Number,
+		}
+		if pick.Seat != nil {
+			row.Seat = pick.Seat.Position
+			if pick.Seat.User != nil {
+				row.Record = records[pick.Seat.User.Id]
+			}
+		}
+		var err error
+		row.Card, err = CardName(pick.Card)
+		if err != nil {
+			return nil, err
+		}
+		for _, card := range pick.Pack {
+			name, err := CardName(card)
+			if err != nil {
+				return nil, err
+			}
+			row.Pack = append(row.Pack, name)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// CardName reads a card's name out of its Scryfall data.
+func CardName(card *schema.Card) (string, error) {
+	var data struct {
+		Scryfall struct {
+			Name string `json:"name"`
+		} `json:"scryfall"`
+	}
+	err := json.Unmarshal([]byte(card.Data), &data)
+	if err != nil {
+		return "", fmt.Errorf("error parsing data for card %d: %w", card.Id, err)
+	}
+	return data.Scryfall.Name, nil
+}
+
+// CSVHeader is the first line of a CSV dataset.
+var CSVHeader = []string{"draft_id", "format", "seat", "round", "pick_number", "card", "pack", "match_wins", "match_losses", "match_draws"}
+
+// WriteCSV writes rows as CSV, with the pack's cards separated by "|". It doesn't write the header, so
+// rows from many drafts can be streamed out one draft at a time.
+func WriteCSV(w io.Writer, rows []Row) error {
+	writer := csv.NewWriter(w)
+	for _, row := range rows {
+		err := writer.Write([]string{
+			strconv.FormatUint(row.DraftID, 10),
+			row.Format,
+			strconv.Itoa(row.Seat),
+			strconv.Itoa(row.Round),
+			strconv.Itoa(row.PickNumber),
+			row.Card,
+			strings.Join(row.Pack, "|"),
+			strconv.Itoa(row.Record.Wins),
+			strconv.Itoa(row.Record.Losses),
+			strconv.Itoa(row.Record.Draws),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSONL writes rows as JSON Lines.
+func WriteJSONL(w io.Writer, rows []Row) error {
+	encoder := json.NewEncoder(w)
+	for _, row := range rows {
+		err := encoder.Encode(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package picktiming works out how long drafters held packs before picking from them.
+//
+// A drafter holds a pack from when it's passed to them, or from their previous pick if that came later,
+// since nobody can pick from two packs at once. Packs opened in the first round are held from when the
+// draft started, and packs opened in later rounds from the drafter's last pick of the round before.
+package picktiming
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// SlowestHolds is how many of the slowest holds Stats lists.
+const SlowestHolds = 5
+
+// Pick is a pick made by the drafter in a seat. At is zero if the pick wasn't timed.
+type Pick struct {
+	Position int
+	Pack     uint64
+	Round    int
+	At       time.Time
+}
+
+// Hold is how long a drafter held a pack before picking from it. Number counts the drafter's picks
+// within the round, starting at 1.
+type Hold struct {
+	Position int
+	Round    int
+	Number   int
+	At       time.Time
+	Duration time.Duration
+}
+
+// PlayerStats are the timing of one drafter's picks. Only timed picks are counted.
+type PlayerStats struct {
+	Position int
+	Picks    int
+	Median   time.Duration
+	Total    time.Duration
+	// Slowest is the drafter's longest hold, or nil if none of their picks were timed.
+	Slowest *Hold
+}
+
+// Stats are the timing of every pick in a draft.
+type Stats struct {
+	// Start is when the draft started, or if that isn't known, its first timed pick. LastPick is its
+	// last timed pick. Both are zero if no picks were timed.
+	Start    time.Time
+	LastPick time.Time
+	Duration time.Duration
+	// Picks counts the holds that could be timed.
+	Picks   int
+	Median  time.Duration
+	Slowest []Hold
+	// Players are ordered by seat position.
+	Players []PlayerStats
+}
+
+// Analyze times picks, which must be in the order they were made. start is when the draft started, or
+// zero if that isn't known.
+func Analyze(start time.Time, picks []Pick) Stats {
+	stats := Stats{Start: start, Slowest: []Hold{}, Players: []PlayerStats{}}
+
+	type seatRound struct{ position, round int }
+	numbers := make(map[seatRound]int)
+	arrivals := make(map[uint64]time.Time)
+	lastPicks := make(map[int]time.Time)
+	var holds []Hold
+	players := make(map[int][]Hold)
+	for _, pick := range picks {
+		key := seatRound{pick.Position, pick.Round}
+		numbers[key]++
+		if _, ok := players[pick.Position]; !ok {
+			players[pick.Position] = []Hold{}
+		}
+
+		held, passed := arrivals[pick.Pack]
+		lastPick, pickedBefore := lastPicks[pick.Position]
+		switch {
+		case (passed && held.IsZero()) || (pickedBefore && lastPick.IsZero()):
+			// The hold might have started at a pick that wasn't timed.
+			held = time.Time{}
+		case !passed && !pickedBefore:
+			held = start
+		case !passed || lastPick.After(held):
+			held = lastPick
+		}
+		arrivals[pick.Pack] = pick.At
+		lastPicks[pick.Position] = pick.At
+		if pick.At.IsZero() {
+			continue
+		}
+		if stats.Start.IsZero() || pick.At.Before(stats.Start) {
+			stats.Start = pick.At
+		}
+		if pick.At.After(stats.LastPick) {
+			stats.LastPick = pick.At
+		}
+		if held.IsZero() {
+			continue
+		}
+
+		hold := Hold{
+			Position: pick.Position,
+			Round:    pick.Round,
+			Number:   numbers[key],
+			At:       pick.At,
+			Duration: max(pick.At.Sub(held), 0),
+		}
+		holds = append(holds, hold)
+		players[pick.Position] = append(players[pick.Position], hold)
+	}
+
+	if !stats.LastPick.IsZero() {
+		stats.Duration = stats.LastPick.Sub(stats.Start)
+	}
+	stats.Picks = len(holds)
+	stats.Median = median(holds)
+	stats.Slowest = slowest(holds, SlowestHolds)
+	for position, playerHolds := range players {
+		player := PlayerStats{
+			Position: position,
+			Picks:    len(playerHolds),
+			Median:   median(playerHolds),
+		}
+		for _, hold := range playerHolds {
+			player.Total += hold.Duration
+		}
+		if slowestHolds := slowest(playerHolds, 1); len(slowestHolds) > 0 {
+			player.Slowest = &slowestHolds[0]
+		}
+		stats.Players = append(stats.Players, player)
+	}
+	slices.SortFunc(stats.Players, func(a, b PlayerStats) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	return stats
+}
+
+func median(holds []Hold) time.Duration {
+	if len(holds) == 0 {
+		return 0
+	}
+	var durations []time.Duration
+	for _, hold := range holds {
+		durations = append(durations, hold.Duration)
+	}
+	slices.Sort(durations)
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[middle-1] + durations[middle]) / 2
+	}
+	return durations[middle]
+}
+
+// slowest lists the n longest holds, longest first. Ties go to the earlier pick.
+func slowest(holds []Hold, n int) []Hold {
+	sorted := slices.Clone(holds)
+	slices.SortStableFunc(sorted, func(a, b Hold) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return sorted[:min(len(sorted), n)]
+}
@@ -0,0 +1,88 @@
+package picktiming
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 5, 1, 19, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return start.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestAnalyze(t *testing.T) {
+	// Two drafters open packs 1 and 2, then swap them.
+	picks := []Pick{
+		{Position: 0, Pack: 1, Round: 1, At: at(10)},
+		{Position: 1, Pack: 2, Round: 1, At: at(30)},
+		// Seat 0 waits for pack 2, so their hold starts when it arrives.
+		{Position: 0, Pack: 2, Round: 1, At: at(40)},
+		// Pack 1 has been waiting for seat 1 since their last pick.
+		{Position: 1, Pack: 1, Round: 1, At: at(90)},
+		// Seat 0 opens their second pack after finishing the first round.
+		{Position: 0, Pack: 3, Round: 2, At: at(100)},
+	}
+	stats := Analyze(start, picks)
+
+	if stats.Start != start || stats.LastPick != at(100) || stats.Duration != 100*time.Second {
+		t.Errorf("expected the draft to last 100s, got %+v", stats)
+	}
+	if stats.Picks != 5 || stats.Median != 30*time.Second {
+		t.Errorf("expected holds of 10s, 30s, 10s, 60s and 60s with a 30s median, got %d picks and %s", stats.Picks, stats.Median)
+	}
+	if len(stats.Slowest) != 5 || stats.Slowest[0].Position != 1 || stats.Slowest[0].Number != 2 ||
+		stats.Slowest[0].Duration != 60*time.Second || stats.Slowest[1].Position != 0 || stats.Slowest[1].Round != 2 {
+		t.Errorf("expected seat 1's second pick to be slowest, then seat 0's first pick of round 2: %+v", stats.Slowest)
+	}
+
+	if len(stats.Players) != 2 {
+		t.Fatalf("expected two players, got %+v", stats.Players)
+	}
+	seat0 := stats.Players[0]
+	if seat0.Position != 0 || seat0.Picks != 3 || seat0.Median != 10*time.Second || seat0.Total != 80*time.Second ||
+		seat0.Slowest == nil || seat0.Slowest.Duration != 60*time.Second {
+		t.Errorf("seat 0's timing is wrong: %+v", seat0)
+	}
+	seat1 := stats.Players[1]
+	if seat1.Picks != 2 || seat1.Median != 45*time.Second || seat1.Total != 90*time.Second {
+		t.Errorf("seat 1's timing is wrong: %+v", seat1)
+	}
+}
+
+func TestAnalyzeSkipsUntimedPicks(t *testing.T) {
+	picks := []Pick{
+		{Position: 0, Pack: 1, Round: 1},
+		{Position: 1, Pack: 1, Round: 1, At: at(20)},
+		{Position: 0, Pack: 2, Round: 1, At: at(50)},
+	}
+	// Without a start time, no hold can be timed: each one starts at a pick that wasn't timed.
+	stats := Analyze(time.Time{}, picks)
+	if stats.Picks != 0 {
+		t.Errorf("expected no holds to be timed, got %+v", stats)
+	}
+	if stats.Start != at(20) || stats.LastPick != at(50) || stats.Duration != 30*time.Second {
+		t.Errorf("expected the draft to be timed from its first timed pick, got %+v", stats)
+	}
+	if len(stats.Players) != 2 || stats.Players[0].Slowest != nil {
+		t.Errorf("expected untimed players to have no slowest hold, got %+v", stats.Players)
+	}
+
+	// Seat 1 picks from their own pack before seat 0's untimed pick is passed to them, but the pack
+	// could have arrived after that, so their second hold can't be timed either.
+	picks = []Pick{
+		{Position: 1, Pack: 2, Round: 1, At: at(5)},
+		{Position: 0, Pack: 1, Round: 1},
+		{Position: 1, Pack: 1, Round: 1, At: at(20)},
+		{Position: 0, Pack: 2, Round: 1, At: at(50)},
+	}
+	stats = Analyze(start, picks)
+	if stats.Picks != 1 || stats.Slowest[0].Position != 1 || stats.Slowest[0].Duration != 5*time.Second {
+		t.Errorf("expected only seat 1's first pick to be timed, got %+v", stats.Slowest)
+	}
+
+	stats = Analyze(time.Time{}, nil)
+	if !stats.Start.IsZero() || stats.Duration != 0 || len(stats.Players) != 0 {
+		t.Errorf("expected empty stats for an empty draft, got %+v", stats)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ServeAPIDraftProgress serves the /api/draftprogress/{draft} endpoint: where each drafter is and
+// who, if anyone, is holding up the draft. No card contents are included.
+func ServeAPIDraftProgress(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/draftprogress/(\d+)`)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(getDraftProgress(draft, time.Now()))
+}
+
+func getDraftProgress(draft *schema.Draft, now time.Time) DraftProgress {
+	progress := DraftProgress{
+		DraftID: int64(draft.Id),
+		State:   draft.State,
+		Seats:   []SeatProgress{},
+	}
+	var blocking *schema.Seat
+	if lifecycle.State(draft.State) == lifecycle.Drafting {
+		blocking = findBlockingSeat(draft)
+	}
+	for _, seat := range draft.Seats {
+		seatProgress := SeatProgress{
+			Position:    seat.Position,
+			Round:       seat.Round,
+			Picks:       len(seat.PickedCards),
+			PacksQueued: len(queuedPacks(seat)),
+			Blocking:    seat == blocking,
+		}
+		if seat.User != nil {
+			userInfo := makeUserInfo(seat.User)
+			seatProgress.Player = &userInfo
+		}
+		if since := oldestQueuedPackSince(draft, seat); !since.IsZero() {
+			seatProgress.OldestQueuedAt = &since
+			seatProgress.OldestQueuedSeconds = now.Sub(since).Seconds()
+		}
+		if seatProgress.Blocking {
+			bottleneck := seatProgress
+			progress.Bottleneck = &bottleneck
+		}
+		progress.Seats = append(progress.Seats, seatProgress)
+	}
+	return progress
+}
+
+// queuedPacks returns the packs a seat can pick from now or once it's done with its current pack.
+// Packs for later rounds are left out.
+func queuedPacks(seat *schema.Seat) []*schema.Pack {
+	var packs []*schema.Pack
+	for _, pack := range seat.Packs {
+		if pack.Round == seat.Round {
+			packs = append(packs, pack)
+		}
+	}
+	return packs
+}
+
+// findBlockingSeat returns the seat holding up the draft: the only seat in the earliest round
+// that still has packs to pick from. It returns nil if no single seat is to blame.
+func findBlockingSeat(draft *schema.Draft) *schema.Seat {
+	if len(draft.Seats) == 0 {
+		return nil
+	}
+	earliestRound := draft.Seats[0].Round
+	for _, seat := range draft.Seats {
+		earliestRound = min(earliestRound, seat.Round)
+	}
+	var blocking *schema.Seat
+	for _, seat := range draft.Seats {
+		if seat.Round != earliestRound || len(queuedPacks(seat)) == 0 {
+			continue
+		}
+		if blocking != nil || seat.User == nil {
+			return nil
+		}
+		blocking = seat
+	}
+	return blocking
+}
+
+// oldestQueuedPackSince returns when the longest-waiting of a seat's queued packs became available
+// to it, or the zero time if that isn't known. A pack becomes available when another seat passes
+// it along; a fresh pack becomes available when the draft starts or the seat finishes the round
+// before.
+func oldestQueuedPackSince(draft *schema.Draft, seat *schema.Seat) time.Time {
+	var oldest time.Time
+	for _, pack := range queuedPacks(seat) {
+		// The latest pick from the pack by another seat is when it was passed here.
+		var passedBy *schema.Event
+		// The seat's latest pick from an earlier round is when it finished that round.
+		var finishedRound *schema.Event
+		for _, event := range draft.Events {
+			if event.Pack != nil && event.Pack.Id == pack.Id && event.Position != seat.Position {
+				if passedBy == nil || event.Id > passedBy.Id {
+					passedBy = event
+				}
+			}
+			if event.Position == seat.Position && event.Round < seat.Round {
+				if finishedRound == nil || event.Id > finishedRound.Id {
+					finishedRound = event
+				}
+			}
+		}
+		var since time.Time
+		switch {
+		case passedBy != nil:
+			since = passedBy.Timestamp
+		case finishedRound != nil:
+			since = finishedRound.Timestamp
+		default:
+			since = draft.StartedAt
+		}
+		if !isTimeSet(since) {
+			continue
+		}
+		if oldest.IsZero() || since.Before(oldest) {
+			oldest = since
+		}
+	}
+	return oldest
+}
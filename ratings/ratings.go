@@ -0,0 +1,210 @@
+// Package ratings computes Elo ratings and season points from match results.
+//
+// Ratings are always recomputed from the whole result log, replayed in a fixed order (by draft, then
+// round, then player), so correcting an old result gives the same ratings as if it had been right all
+// along.
+package ratings
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	// Initial is everyone's rating before their first match.
+	Initial = 1500.0
+	// K is the most a rating can change in one match.
+	K = 32.0
+)
+
+// Points awarded for each match result.
+const (
+	WinPoints  = 3
+	DrawPoints = 1
+)
+
+// NoOpponent is the opponent of a result whose opponent wasn't recorded.
+const NoOpponent uint64 = 0
+
+// Result is one player's result in one round of a draft.
+type Result struct {
+	Draft  uint64
+	Round  int
+	Time   time.Time
+	Player uint64
+	// Opponent is NoOpponent for results recorded before opponents were. Those are rated against the
+	// average rating of everyone else who played in the round.
+	Opponent uint64
+	// Bye results earn points but don't change ratings.
+	Bye        bool
+	GamesWon   int
+	GamesLost  int
+	GamesDrawn int
+}
+
+// Score is 1 for a match win, 0.5 for a draw and 0 for a loss.
+func (r Result) Score() float64 {
+	switch {
+	case r.Bye || r.GamesWon > r.GamesLost:
+		return 1
+	case r.GamesWon < r.GamesLost:
+		return 0
+	}
+	return 0.5
+}
+
+// Points are the season points the result is worth.
+func (r Result) Points() int {
+	switch r.Score() {
+	case 1:
+		return WinPoints
+	case 0.5:
+		return DrawPoints
+	}
+	return 0
+}
+
+// Entry is a result along with the player's rating before and after it.
+type Entry struct {
+	Result
+	Before float64
+	After  float64
+}
+
+// Ratings are every player's current rating and how they got there.
+type Ratings struct {
+	Current map[uint64]float64
+	History map[uint64][]Entry
+}
+
+// Rating returns a player's current rating.
+func (r Ratings) Rating(player uint64) float64 {
+	if rating, ok := r.Current[player]; ok {
+		return rating
+	}
+	return Initial
+}
+
+// Compute replays results in order. Everyone in a round is rated against their opponents' ratings from
+// before the round, so the order of results within a round doesn't matter.
+func Compute(results []Result) Ratings {
+	results = slices.Clone(results)
+	slices.SortStableFunc(results, func(a, b Result) int {
+		return cmp.Or(
+			cmp.Compare(a.Draft, b.Draft),
+			cmp.Compare(a.Round, b.Round),
+			cmp.Compare(a.Player, b.Player),
+		)
+	})
+
+	ratings := Ratings{
+		Current: make(map[uint64]float64),
+		History: make(map[uint64][]Entry),
+	}
+	for start := 0; start < len(results); {
+		end := start + 1
+		for end < len(results) && results[end].Draft == results[start].Draft &&
+			results[end].Round == results[start].Round {
+			end++
+		}
+		round := results[start:end]
+		start = end
+
+		before := make(map[uint64]float64)
+		for _, result := range round {
+			before[result.Player] = ratings.Rating(result.Player)
+			if result.Opponent != NoOpponent {
+				before[result.Opponent] = ratings.Rating(result.Opponent)
+			}
+		}
+		for _, result := range round {
+			rating := before[result.Player]
+			entry := Entry{Result: result, Before: rating, After: rating}
+			if !result.Bye {
+				opponentRating, ok := opponentRating(result, round, before)
+				if ok {
+					entry.After = rating + K*(result.Score()-expectedScore(rating, opponentRating))
+				}
+			}
+			ratings.Current[result.Player] = entry.After
+			ratings.History[result.Player] = append(ratings.History[result.Player], entry)
+		}
+	}
+	return ratings
+}
+
+// opponentRating is the rating a result is rated against: the opponent's, or if that's unknown, the
+// average of everyone else who played in the round.
+func opponentRating(result Result, round []Result, before map[uint64]float64) (float64, bool) {
+	if result.Opponent != NoOpponent {
+		return before[result.Opponent], true
+	}
+	total := 0.0
+	count := 0
+	for _, other := range round {
+		if other.Player != result.Player && !other.Bye {
+			total += before[other.Player]
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+func expectedScore(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// Standing is a player's place on a leaderboard.
+type Standing struct {
+	Player uint64
+	Points int
+	Wins   int
+	Losses int
+	Draws  int
+	Drafts int
+	// Rating is the player's rating after their last result on the leaderboard.
+	Rating float64
+}
+
+// Leaderboard ranks everyone who played between from and to by points and then rating. A zero time
+// leaves that end of the range open.
+func Leaderboard(ratings Ratings, from, to time.Time) []Standing {
+	var standings []Standing
+	for player, history := range ratings.History {
+		standing := Standing{Player: player}
+		drafts := make(map[uint64]bool)
+		for _, entry := range history {
+			if (!from.IsZero() && entry.Time.Before(from)) || (!to.IsZero() && !entry.Time.Before(to)) {
+				continue
+			}
+			drafts[entry.Draft] = true
+			standing.Points += entry.Points()
+			switch entry.Score() {
+			case 1:
+				standing.Wins++
+			case 0:
+				standing.Losses++
+			default:
+				standing.Draws++
+			}
+			standing.Rating = entry.After
+		}
+		if len(drafts) > 0 {
+			standing.Drafts = len(drafts)
+			standings = append(standings, standing)
+		}
+	}
+	slices.SortFunc(standings, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(b.Rating, a.Rating),
+			cmp.Compare(a.Player, b.Player),
+		)
+	})
+	return standings
+}
@@ -0,0 +1,128 @@
+package ratings
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// match is both sides of a result between two players.
+func match(draft uint64, round int, winner, loser uint64, winnerGames, loserGames int) []Result {
+	return []Result{
+		{Draft: draft, Round: round, Player: winner, Opponent: loser, GamesWon: winnerGames, GamesLost: loserGames},
+		{Draft: draft, Round: round, Player: loser, Opponent: winner, GamesWon: loserGames, GamesLost: winnerGames},
+	}
+}
+
+func TestEvenMatchMovesRatingsByHalfOfK(t *testing.T) {
+	ratings := Compute(match(1, 1, 1, 2, 2, 1))
+	if !near(ratings.Rating(1), Initial+K/2) || !near(ratings.Rating(2), Initial-K/2) {
+		t.Errorf("expected ratings of %f and %f, got %v", Initial+K/2, Initial-K/2, ratings.Current)
+	}
+	if !near(ratings.Rating(3), Initial) {
+		t.Errorf("expected a player with no results to be rated %f, got %f", Initial, ratings.Rating(3))
+	}
+}
+
+func TestComputeIsDeterministic(t *testing.T) {
+	var results []Result
+	results = append(results, match(1, 1, 1, 2, 2, 0)...)
+	results = append(results, match(1, 1, 3, 4, 2, 1)...)
+	results = append(results, match(1, 2, 1, 3, 2, 1)...)
+	results = append(results, match(1, 2, 4, 2, 2, 0)...)
+	results = append(results, match(2, 1, 2, 1, 2, 1)...)
+	expected := Compute(results)
+
+	for range 10 {
+		rand.Shuffle(len(results), func(i, j int) {
+			results[i], results[j] = results[j], results[i]
+		})
+		shuffled := Compute(results)
+		for player, rating := range expected.Current {
+			if !near(shuffled.Rating(player), rating) {
+				t.Fatalf("expected player %d to be rated %f regardless of order, got %f",
+					player, rating, shuffled.Rating(player))
+			}
+		}
+	}
+}
+
+func TestRoundsUseRatingsFromBeforeTheRound(t *testing.T) {
+	var results []Result
+	results = append(results, match(1, 1, 1, 2, 2, 0)...)
+	results = append(results, match(1, 2, 1, 3, 2, 0)...)
+	ratings := Compute(results)
+	history := ratings.History[1]
+	if len(history) != 2 {
+		t.Fatalf("expected two history entries, got %d", len(history))
+	}
+	if !near(history[1].Before, history[0].After) {
+		t.Errorf("expected round 2 to start from round 1's rating, got %+v", history)
+	}
+	// Player 1 was favored in round 2, so they gained less than in round 1.
+	if history[1].After-history[1].Before >= history[0].After-history[0].Before {
+		t.Errorf("expected a smaller gain against a weaker opponent, got %+v", history)
+	}
+}
+
+func TestByesAndUnknownOpponents(t *testing.T) {
+	results := []Result{
+		{Draft: 1, Round: 1, Player: 1, Bye: true, GamesWon: 2},
+		{Draft: 1, Round: 1, Player: 2, GamesWon: 2},
+		{Draft: 1, Round: 1, Player: 3, GamesLost: 2},
+	}
+	ratings := Compute(results)
+	if !near(ratings.Rating(1), Initial) {
+		t.Errorf("expected a bye not to change the rating, got %f", ratings.Rating(1))
+	}
+	// Unknown opponents are rated against the rest of the round, who are all at the initial rating.
+	if !near(ratings.Rating(2), Initial+K/2) || !near(ratings.Rating(3), Initial-K/2) {
+		t.Errorf("expected unknown opponents to be rated against the field, got %v", ratings.Current)
+	}
+	if results[0].Points() != WinPoints {
+		t.Errorf("expected a bye to be worth %d points, got %d", WinPoints, results[0].Points())
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	jan := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	var results []Result
+	for _, result := range match(1, 1, 1, 2, 2, 0) {
+		result.Time = jan
+		results = append(results, result)
+	}
+	for _, result := range match(2, 1, 2, 3, 2, 1) {
+		result.Time = mar
+		results = append(results, result)
+	}
+	draw := []Result{
+		{Draft: 2, Round: 2, Time: mar, Player: 2, Opponent: 1, GamesWon: 1, GamesLost: 1},
+		{Draft: 2, Round: 2, Time: mar, Player: 1, Opponent: 2, GamesWon: 1, GamesLost: 1},
+	}
+	results = append(results, draw...)
+	ratings := Compute(results)
+
+	season := Leaderboard(ratings, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if len(season) != 3 {
+		t.Fatalf("expected three players in the season, got %+v", season)
+	}
+	first := season[0]
+	if first.Player != 2 || first.Points != WinPoints+DrawPoints || first.Wins != 1 || first.Draws != 1 ||
+		first.Drafts != 1 || !near(first.Rating, ratings.Rating(2)) {
+		t.Errorf("expected player 2 to lead the season, got %+v", first)
+	}
+	if season[1].Player != 1 || season[1].Points != DrawPoints {
+		t.Errorf("expected player 1's January win not to count, got %+v", season[1])
+	}
+
+	allTime := Leaderboard(ratings, time.Time{}, time.Time{})
+	if allTime[0].Points != allTime[1].Points || allTime[0].Rating < allTime[1].Rating {
+		t.Errorf("expected ties on points to be broken by rating, got %+v", allTime)
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/pairings"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// Statuses of a pairing's result.
+const (
+	ResultPending   = ""
+	ResultReported  = "reported"
+	ResultConfirmed = "confirmed"
+	ResultDisputed  = "disputed"
+)
+
+// ResultError is returned when a match result can't be reported, confirmed or disputed.
+var ResultError = fmt.Errorf("invalid match result")
+
+// ServeAPIResult serves the /api/result/{draft} endpoint.
+// GET returns the user's match in the current round. POST reports its result, confirms or disputes the
+// opponent's report, or, for the admin, settles a dispute.
+func ServeAPIResult(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/result/(\d+)`)
+	if err != nil {
+		return err
+	}
+
+	var posted PostedResult
+	switch r.Method {
+	case "GET":
+	case "POST":
+		bodyBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("error reading post body: %w", err)
+		}
+		err = json.Unmarshal(bodyBytes, &posted)
+		if err != nil {
+			return fmt.Errorf("error parsing post body: %w", err)
+		}
+	default:
+		return MethodNotAllowedError
+	}
+
+	round := posted.Round
+	if round == 0 {
+		matches, err := loadMatches(ob, draft)
+		if err != nil {
+			return err
+		}
+		round = pairings.LastRound(matches)
+	}
+	playerID := uint64(userID)
+	if posted.Action == "resolve" {
+		if userID != 1 {
+			return fmt.Errorf("not allowed")
+		}
+		playerID = uint64(posted.User)
+	}
+	user, err := schema.BoxForUser(ob).Get(playerID)
+	if err != nil {
+		return fmt.Errorf("error loading user %d: %w", playerID, err)
+	}
+	if user == nil {
+		return fmt.Errorf("couldn't find user %d", playerID)
+	}
+	pairing, err := findPairing(ob, draft, round, user)
+	if err != nil {
+		return err
+	}
+
+	if r.Method == "POST" {
+		switch posted.Action {
+		case "report":
+			err = reportResult(ob, draft, pairing, user, posted.Wins, posted.Losses, posted.Draws)
+		case "confirm":
+			err = confirmResult(ob, draft, pairing, user)
+		case "dispute":
+			err = disputeResult(ob, draft, pairing, user)
+		case "resolve":
+			err = resolveResult(ob, draft, pairing, user, posted.Wins, posted.Losses, posted.Draws)
+		default:
+			err = fmt.Errorf("%w: unknown action %q", ResultError, posted.Action)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.NewEncoder(w).Encode(pairingToJSON(pairing))
+}
+
+// findPairing returns a user's pairing in a round of a draft.
+func findPairing(ob *objectbox.ObjectBox, draft *schema.Draft, round int, user *schema.User) (*schema.Pairing, error) {
+	pairingRows, err := schema.BoxForPairing(ob).Query(
+		schema.Pairing_.Draft.Equals(draft.Id),
+		schema.Pairing_.Round.Equals(round)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("error loading pairings for draft %d: %w", draft.Id, err)
+	}
+	for _, pairing := range pairingRows {
+		if (pairing.Player1 != nil && pairing.Player1.Id == user.Id) ||
+			(pairing.Player2 != nil && pairing.Player2.Id == user.Id) {
+			return pairing, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: user %d isn't paired in round %d of draft %d", ResultError, user.Id, round, draft.Id)
+}
+
+// opponent returns the other player in a pairing, or nil for a bye.
+func opponent(pairing *schema.Pairing, user *schema.User) *schema.User {
+	if pairing.Player1.Id == user.Id {
+		return pairing.Player2
+	}
+	return pairing.Player1
+}
+
+// checkReportable makes sure a pairing's result can still be changed by its players.
+func checkReportable(draft *schema.Draft, pairing *schema.Pairing) error {
+	err := checkDraftState(draft, "report a result", func(state lifecycle.State) bool {
+		return state == lifecycle.Playing
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case pairing.Player2 == nil:
+		return fmt.Errorf("%w: byes don't need to be reported", ResultError)
+	case pairing.Status == ResultConfirmed:
+		return fmt.Errorf("%w: the result of this match is already confirmed", ResultError)
+	case pairing.Status == ResultDisputed:
+		return fmt.Errorf("%w: the result of this match is disputed and waiting for the admin", ResultError)
+	}
+	return nil
+}
+
+// reportResult records a player's report of their match's score. If their opponent already reported
+// the same score, that confirms the result; if they reported a different one, it's disputed.
+func reportResult(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing, user *schema.User, wins, losses, draws int) error {
+	err := checkReportable(draft, pairing)
+	if err != nil {
+		return err
+	}
+	err = checkScore(wins, losses, draws)
+	if err != nil {
+		return err
+	}
+	wins1, wins2 := wins, losses
+	if pairing.Player2.Id == user.Id {
+		wins1, wins2 = losses, wins
+	}
+	if pairing.Status == ResultReported && pairing.ReportedBy.Id != user.Id {
+		if pairing.Wins1 == wins1 && pairing.Wins2 == wins2 && pairing.Draws == draws {
+			return finishMatch(ob, draft, pairing)
+		}
+		return disputeResult(ob, draft, pairing, user)
+	}
+
+	pairing.Status = ResultReported
+	pairing.ReportedBy = user
+	pairing.Wins1, pairing.Wins2, pairing.Draws = wins1, wins2, draws
+	_, err = schema.BoxForPairing(ob).Put(pairing)
+	if err != nil {
+		return fmt.Errorf("error saving result: %w", err)
+	}
+	queueDraftUpdate(draft, DraftUpdateResult)
+	other := opponent(pairing, user)
+	if len(other.DiscordId) > 0 {
+		err = DiscordDirectMessage(other.DiscordId,
+			fmt.Sprintf("%s reported your round %d match in *%s* as %s for you. "+
+				"Please confirm or dispute it at <https://draftcu.be/draft/%d>.",
+				user.DiscordName, pairing.Round, draft.Name, formatScore(losses, wins, draws), draft.Id))
+		if err != nil {
+			log.Printf("error asking user %d to confirm a result: %s", other.Id, err.Error())
+		}
+	}
+	return nil
+}
+
+// checkScore makes sure a score, from one player's point of view, could come from a best-of-three match.
+func checkScore(wins, losses, draws int) error {
+	if wins < 0 || losses < 0 || draws < 0 || wins > 2 || losses > 2 || (wins == 2 && losses == 2) ||
+		wins+losses+draws == 0 {
+		return fmt.Errorf("%w: %s isn't a possible score", ResultError, formatScore(wins, losses, draws))
+	}
+	return nil
+}
+
+// formatScore writes a score as wins-losses, with draws on the end if there were any.
+func formatScore(wins, losses, draws int) string {
+	if draws > 0 {
+		return fmt.Sprintf("%d-%d-%d", wins, losses, draws)
+	}
+	return fmt.Sprintf("%d-%d", wins, losses)
+}
+
+// confirmResult accepts the opponent's report.
+func confirmResult(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing, user *schema.User) error {
+	err := checkReportable(draft, pairing)
+	if err != nil {
+		return err
+	}
+	if pairing.Status != ResultReported || pairing.ReportedBy.Id == user.Id {
+		return fmt.Errorf("%w: there's no report from your opponent to confirm", ResultError)
+	}
+	return finishMatch(ob, draft, pairing)
+}
+
+// disputeResult rejects the opponent's report and asks the admin to settle it.
+func disputeResult(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing, user *schema.User) error {
+	err := checkReportable(draft, pairing)
+	if err != nil {
+		return err
+	}
+	if pairing.Status != ResultReported || pairing.ReportedBy.Id == user.Id {
+		return fmt.Errorf("%w: there's no report from your opponent to dispute", ResultError)
+	}
+	pairing.Status = ResultDisputed
+	_, err = schema.BoxForPairing(ob).Put(pairing)
+	if err != nil {
+		return fmt.Errorf("error saving dispute: %w", err)
+	}
+	queueDraftUpdate(draft, DraftUpdateResult)
+	adminDiscordID, err := GetAdminDiscordId(ob)
+	if err != nil {
+		return err
+	}
+	err = DiscordNotify(os.Getenv("PICK_ALERTS_CHANNEL_ID"),
+		fmt.Sprintf("<@%s> %s and %s disagree about who won their round %d match in *%s* (draft %d).",
+			adminDiscordID, pairing.Player1.DiscordName, pairing.Player2.DiscordName, pairing.Round,
+			draft.Name, draft.Id))
+	if err != nil {
+		log.Printf("error notifying admin of a dispute: %s", err.Error())
+	}
+	return nil
+}
+
+// withdrawResult takes back a report of a score, from user's point of view, that the opponent hasn't
+// responded to yet. Nothing happens if user has since reported a different score.
+func withdrawResult(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing, user *schema.User, wins, losses, draws int) error {
+	err := checkDraftState(draft, "withdraw a result", func(state lifecycle.State) bool {
+		return state == lifecycle.Playing
+	})
+	if err != nil {
+		return err
+	}
+	if pairing.Status != ResultReported || pairing.ReportedBy.Id != user.Id {
+		return nil
+	}
+	reportedWins, reportedLosses := pairing.Wins1, pairing.Wins2
+	if pairing.Player2 != nil && pairing.Player2.Id == user.Id {
+		reportedWins, reportedLosses = pairing.Wins2, pairing.Wins1
+	}
+	if reportedWins != wins || reportedLosses != losses || pairing.Draws != draws {
+		return nil
+	}
+	pairing.Status = ResultPending
+	pairing.ReportedBy = nil
+	pairing.Wins1, pairing.Wins2, pairing.Draws = 0, 0, 0
+	_, err = schema.BoxForPairing(ob).Put(pairing)
+	if err != nil {
+		return err
+	}
+	queueDraftUpdate(draft, DraftUpdateResult)
+	return nil
+}
+
+// resolveResult is the admin deciding a match's score, given from user's point of view.
+func resolveResult(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing, user *schema.User, wins, losses, draws int) error {
+	err := checkDraftState(draft, "resolve a result", func(state lifecycle.State) bool {
+		return state == lifecycle.Playing
+	})
+	if err != nil {
+		return err
+	}
+	if pairing.Player2 == nil || pairing.Status == ResultConfirmed {
+		return fmt.Errorf("%w: the result of this match is already confirmed", ResultError)
+	}
+	err = checkScore(wins, losses, draws)
+	if err != nil {
+		return err
+	}
+	pairing.Wins1, pairing.Wins2, pairing.Draws = wins, losses, draws
+	if pairing.Player2.Id == user.Id {
+		pairing.Wins1, pairing.Wins2 = losses, wins
+	}
+	return finishMatch(ob, draft, pairing)
+}
+
+// finishMatch confirms a match's score, records it for both players and pairs the next round if this
+// was the last match outstanding.
+func finishMatch(ob *objectbox.ObjectBox, draft *schema.Draft, pairing *schema.Pairing) error {
+	pairing.Status = ResultConfirmed
+	_, err := schema.BoxForPairing(ob).Put(pairing)
+	if err != nil {
+		return fmt.Errorf("error saving result: %w", err)
+	}
+	for _, result := range []*schema.Result{
+		{User: pairing.Player1, GamesWon: pairing.Wins1, GamesLost: pairing.Wins2},
+		{User: pairing.Player2, GamesWon: pairing.Wins2, GamesLost: pairing.Wins1},
+	} {
+		result.Draft = draft
+		result.Round = pairing.Round
+		// Kept for older readers. A draw is stored as false, so read the games instead.
+		result.Win = result.GamesWon > result.GamesLost
+		result.GamesDrawn = pairing.Draws
+		result.Timestamp = time.Now()
+		_, err = schema.BoxForResult(ob).Put(result)
+		if err != nil {
+			return fmt.Errorf("error saving result: %w", err)
+		}
+	}
+	queueDraftUpdate(draft, DraftUpdateResult)
+	CheckNextRoundPairings(ob, draft, pairing.Round)
+	return nil
+}
+
+// pairingToJSON describes a pairing to the client. Scores are included once one has been reported.
+func pairingToJSON(pairing *schema.Pairing) MatchJSON {
+	match := MatchJSON{
+		Player1:  makeUserInfo(pairing.Player1),
+		Wins1:    pairing.Wins1,
+		Wins2:    pairing.Wins2,
+		Draws:    pairing.Draws,
+		Reported: pairing.Status == ResultConfirmed,
+		Status:   pairing.Status,
+	}
+	if pairing.Player2 != nil {
+		player2 := makeUserInfo(pairing.Player2)
+		match.Player2 = &player2
+	}
+	if pairing.ReportedBy != nil {
+		reportedBy := int64(pairing.ReportedBy.Id)
+		match.ReportedBy = &reportedBy
+	}
+	return match
+}
+
+// resultReaction is a reaction players add to a pairings message to report their score.
+type resultReaction struct {
+	emoji               string
+	wins, losses, draws int
+}
+
+// resultReactions are the scores that can be reported by reacting to a pairings message.
+var resultReactions = []resultReaction{
+	{"🏆", 2, 0, 0},
+	{"🎉", 2, 1, 0},
+	{"🤝", 1, 1, 0},
+	{"😓", 1, 2, 0},
+	{"💀", 0, 2, 0},
+}
+
+// resultReactionsHelp explains resultReactions in the footer of a pairings message.
+func resultReactionsHelp() string {
+	var scores []string
+	for _, reaction := range resultReactions {
+		scores = append(scores, fmt.Sprintf("%s %s", reaction.emoji,
+			formatScore(reaction.wins, reaction.losses, reaction.draws)))
+	}
+	return "React with your score: " + strings.Join(scores, ", ") + "."
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ReminderLeadTime is how long before a scheduled start joined players are reminded.
+const ReminderLeadTime = time.Hour
+
+// ProcessScheduledDrafts opens, reminds and starts drafts that were created with a schedule.
+// It is run periodically by the scheduler in main.
+func ProcessScheduledDrafts(ob *objectbox.ObjectBox) error {
+	err := runInWriteTx(ob, func() error {
+		now := time.Now()
+		draftBox := schema.BoxForDraft(ob)
+
+		scheduled, err := draftBox.Query(schema.Draft_.State.Equals(string(lifecycle.Scheduled), true)).Find()
+		if err != nil {
+			return err
+		}
+		for _, draft := range scheduled {
+			if !draft.OpenAt.After(now) {
+				err = transitionDraft(ob, draft, lifecycle.Open)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		open, err := draftBox.Query(schema.Draft_.State.Equals(string(lifecycle.Open), true)).Find()
+		if err != nil {
+			return err
+		}
+		for _, draft := range open {
+			if !isTimeSet(draft.StartAt) {
+				continue
+			}
+			if !draft.StartAt.After(now) {
+				full := !slices.ContainsFunc(draft.Seats, func(seat *schema.Seat) bool {
+					return seat.User == nil
+				})
+				if full {
+					err = transitionDraft(ob, draft, lifecycle.Drafting)
+					if err != nil {
+						return err
+					}
+				} else if !draft.StartDelayNotified {
+					// Joining the last seat starts the draft, now that its start time has passed.
+					err = notifyAdminOfUnfilledDraft(ob, draft)
+					if err != nil {
+						log.Printf("error notifying admin of unfilled draft %d: %s", draft.Id, err.Error())
+					}
+					draft.StartDelayNotified = true
+					_, err = draftBox.Put(draft)
+					if err != nil {
+						return err
+					}
+				}
+			} else if !draft.ReminderSent && !draft.StartAt.After(now.Add(ReminderLeadTime)) {
+				err = remindJoinedPlayers(draft)
+				if err != nil {
+					log.Printf("error reminding players of draft %d: %s", draft.Id, err.Error())
+				}
+				draft.ReminderSent = true
+				_, err = draftBox.Put(draft)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error processing scheduled drafts: %w", err)
+	}
+	return nil
+}
+
+// remindJoinedPlayers pings everyone who has joined a draft that it starts soon.
+func remindJoinedPlayers(draft *schema.Draft) error {
+	var mentions string
+	for _, seat := range draft.Seats {
+		if seat.User != nil && seat.User.DiscordId != "" {
+			mentions += fmt.Sprintf("<@%s> ", seat.User.DiscordId)
+		}
+	}
+	if mentions == "" {
+		return nil
+	}
+	return DiscordNotify(os.Getenv("PICK_ALERTS_CHANNEL_ID"),
+		fmt.Sprintf(`%s*%s* starts <t:%d:R> <https://draftcu.be/draft/%d>`,
+			mentions, draft.Name, draft.StartAt.Unix(), draft.Id))
+}
+
+// notifyAdminOfUnfilledDraft tells the admin that a scheduled draft couldn't start because it has
+// empty seats.
+func notifyAdminOfUnfilledDraft(ob *objectbox.ObjectBox, draft *schema.Draft) error {
+	adminDiscordId, err := GetAdminDiscordId(ob)
+	if err != nil {
+		return err
+	}
+	filled := 0
+	for _, seat := range draft.Seats {
+		if seat.User != nil {
+			filled++
+		}
+	}
+	return DiscordNotify(os.Getenv("PICK_ALERTS_CHANNEL_ID"),
+		fmt.Sprintf(`<@%s> draft %d was due to start, but only %d of %d seats are filled. It will start once the rest are, or use startdraft %d to start it now.`,
+			adminDiscordId, draft.Id, filled, len(draft.Seats), draft.Id))
+}
+
+// announceDraftOpenHook posts a sign-up message once a scheduled draft opens for joining.
+func announceDraftOpenHook(_ *objectbox.ObjectBox, draft *schema.Draft, transition lifecycle.Transition) error {
+	if transition.From != lifecycle.Scheduled || transition.To != lifecycle.Open {
+		return nil
+	}
+	description := fmt.Sprintf("Sign up at <https://draftcu.be/draft/%d>.", draft.Id)
+	if isTimeSet(draft.StartAt) {
+		description += fmt.Sprintf("\n\nThe draft starts <t:%d:F>.", draft.StartAt.Unix())
+	}
+	_, err := DiscordNotifyEmbed(
+		os.Getenv("DRAFT_ANNOUNCEMENTS_CHANNEL_ID"),
+		&discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("%s is open!", draft.Name),
+			Description: description,
+			Color:       Pink,
+		})
+	if err != nil {
+		log.Printf("error announcing draft %d: %s", draft.Id, err.Error())
+	}
+	return nil
+}
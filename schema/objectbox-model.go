@@ -22,9 +22,14 @@ func ObjectBoxModel() *objectbox.Model {
 	model.RegisterBinding(RoleMsgBinding)
 	model.RegisterBinding(PairingMsgBinding)
 	model.RegisterBinding(ResultBinding)
-	model.LastEntityId(10, 3741662715507038888)
-	model.LastIndexId(15, 722712850427169033)
-	model.LastRelationId(9, 6610520635914740722)
+	model.RegisterBinding(WaitlistEntryBinding)
+	model.RegisterBinding(FormatPrefBinding)
+	model.RegisterBinding(DeckBinding)
+	model.RegisterBinding(PairingBinding)
+	model.RegisterBinding(SeasonBinding)
+	model.LastEntityId(15, 6968326560255471446)
+	model.LastIndexId(25, 5759429986930868238)
+	model.LastRelationId(13, 8721412134954118689)
 
 	return model
 }
@@ -20,7 +20,31 @@ type Draft struct {
 	Events             []*Event
 	SpectatorChannelId string `objectbox:"index"`
 	PickTwo            bool
-	Archived           bool `objectbox:"index"`
+	Archived           bool      `objectbox:"index"`
+	State              string    `objectbox:"index"`
+	StartedAt          time.Time `objectbox:"date"`
+	OpenAt             time.Time `objectbox:"date"`
+	StartAt            time.Time `objectbox:"date"`
+	ReminderSent       bool
+	// StartDelayNotified is set once the admin has been told a scheduled draft reached its start time
+	// with empty seats.
+	StartDelayNotified bool
+	Waitlist           []*WaitlistEntry
+	// Rounds is how many rounds of Swiss are played after an online draft. Zero means enough rounds
+	// for one player to finish undefeated.
+	Rounds int
+	// Modified is the latest DraftModified value handed out. Picks and undos both advance it, so
+	// values are never reused. Drafts from before it was kept count from their events instead.
+	Modified int
+	// UndoneModified is the Modified value of the draft's latest undo, and UndonePickModified is the
+	// Modified value of the pick it undid. Anything fetched between the two may include the undone pick.
+	UndoneModified     int
+	UndonePickModified int
+	// SpectatorDelayPicks is how many picks behind the slowest drafter spectators are kept until the
+	// draft is over.
+	SpectatorDelayPicks int
+	// SpectatorDelaySeconds is how old a pick must be before spectators see it, until the draft is over.
+	SpectatorDelaySeconds int
 }
 
 type Pack struct {
@@ -41,6 +65,7 @@ type Seat struct {
 	Packs         []*Pack
 	OriginalPacks []*Pack
 	PickedCards   []*Card
+	ReservedUntil time.Time `objectbox:"date"`
 }
 
 type User struct {
@@ -50,6 +75,7 @@ type User struct {
 	MtgoName    string
 	Picture     string
 	Skips       []*Skip
+	FormatPrefs []*FormatPref
 }
 
 type Event struct {
@@ -61,6 +87,28 @@ type Event struct {
 	Pack         *Pack `objectbox:"link"`
 	Modified     int
 	Round        int
+	// Timestamp is when the pick was made. Events from before picks were timed don't have one.
+	Timestamp time.Time `objectbox:"date"`
+}
+
+type Deck struct {
+	Id        uint64
+	Seat      *Seat `objectbox:"link"`
+	MainDeck  []*Card
+	Sideboard []*Card
+	Plains    int
+	Islands   int
+	Swamps    int
+	Mountains int
+	Forests   int
+	Locked    bool
+	LockedAt  time.Time `objectbox:"date"`
+}
+
+type WaitlistEntry struct {
+	Id        uint64
+	User      *User     `objectbox:"link"`
+	Timestamp time.Time `objectbox:"date"`
 }
 
 type Skip struct {
@@ -68,6 +116,12 @@ type Skip struct {
 	DraftId uint64
 }
 
+type FormatPref struct {
+	Id     uint64
+	Format string `objectbox:"index"`
+	Elig   bool
+}
+
 type RoleMsg struct {
 	Id     uint64
 	MsgId  string `objectbox:"index"`
@@ -82,11 +136,42 @@ type PairingMsg struct {
 	Round int
 }
 
+// Pairing is one match in a round of an online draft. Player2 is nil if Player1 has a bye.
+// One player reports the score and the other confirms or disputes it. Wins1 and Wins2 are the games
+// won by each player.
+type Pairing struct {
+	Id         uint64
+	Draft      *Draft `objectbox:"link"`
+	Round      int
+	Player1    *User `objectbox:"link"`
+	Player2    *User `objectbox:"link"`
+	Status     string
+	ReportedBy *User `objectbox:"link"`
+	Wins1      int
+	Wins2      int
+	Draws      int
+}
+
+// Season is a range of dates that results are ranked over. Drafts started on or after StartAt and
+// before EndAt count towards it.
+type Season struct {
+	Id      uint64
+	Name    string
+	StartAt time.Time `objectbox:"date"`
+	EndAt   time.Time `objectbox:"date"`
+}
+
+// Result is one player's confirmed result in a round. GamesWon, GamesLost and GamesDrawn are their
+// games in the match.
 type Result struct {
-	Id        uint64
-	Draft     *Draft `objectbox:"link"`
-	Round     int
-	User      *User `objectbox:"link"`
-	Win       bool
-	Timestamp time.Time `objectbox:"date"`
+	Id    uint64
+	Draft *Draft `objectbox:"link"`
+	Round int
+	User  *User `objectbox:"link"`
+	// Deprecated: Win can't tell a draw from a loss. Compare GamesWon and GamesLost instead.
+	Win        bool
+	GamesWon   int
+	GamesLost  int
+	GamesDrawn int
+	Timestamp  time.Time `objectbox:"date"`
 }
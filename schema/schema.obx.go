@@ -363,16 +363,29 @@ var DraftBinding = draft_EntityInfo{
 
 // Draft_ contains type-based Property helpers to facilitate some common operations such as Queries.
 var Draft_ = struct {
-	Id                 *objectbox.PropertyUint64
-	Name               *objectbox.PropertyString
-	Format             *objectbox.PropertyString
-	InPerson           *objectbox.PropertyBool
-	SpectatorChannelId *objectbox.PropertyString
-	PickTwo            *objectbox.PropertyBool
-	Archived           *objectbox.PropertyBool
-	Seats              *objectbox.RelationToMany
-	UnassignedPacks    *objectbox.RelationToMany
-	Events             *objectbox.RelationToMany
+	Id                    *objectbox.PropertyUint64
+	Name                  *objectbox.PropertyString
+	Format                *objectbox.PropertyString
+	InPerson              *objectbox.PropertyBool
+	SpectatorChannelId    *objectbox.PropertyString
+	PickTwo               *objectbox.PropertyBool
+	Archived              *objectbox.PropertyBool
+	State                 *objectbox.PropertyString
+	StartedAt             *objectbox.PropertyInt64
+	OpenAt                *objectbox.PropertyInt64
+	StartAt               *objectbox.PropertyInt64
+	ReminderSent          *objectbox.PropertyBool
+	Rounds                *objectbox.PropertyInt
+	Modified              *objectbox.PropertyInt
+	UndoneModified        *objectbox.PropertyInt
+	SpectatorDelayPicks   *objectbox.PropertyInt
+	SpectatorDelaySeconds *objectbox.PropertyInt
+	StartDelayNotified    *objectbox.PropertyBool
+	UndonePickModified    *objectbox.PropertyInt
+	Seats                 *objectbox.RelationToMany
+	UnassignedPacks       *objectbox.RelationToMany
+	Events                *objectbox.RelationToMany
+	Waitlist              *objectbox.RelationToMany
 }{
 	Id: &objectbox.PropertyUint64{
 		BaseProperty: &objectbox.BaseProperty{
@@ -416,6 +429,78 @@ var Draft_ = struct {
 			Entity: &DraftBinding.Entity,
 		},
 	},
+	State: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	StartedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	OpenAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	StartAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	ReminderSent: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     12,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	Rounds: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     13,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	Modified: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     14,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	UndoneModified: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     15,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	SpectatorDelayPicks: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     16,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	SpectatorDelaySeconds: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     17,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	StartDelayNotified: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     18,
+			Entity: &DraftBinding.Entity,
+		},
+	},
+	UndonePickModified: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     19,
+			Entity: &DraftBinding.Entity,
+		},
+	},
 	Seats: &objectbox.RelationToMany{
 		Id:     1,
 		Source: &DraftBinding.Entity,
@@ -431,6 +516,11 @@ var Draft_ = struct {
 		Source: &DraftBinding.Entity,
 		Target: &EventBinding.Entity,
 	},
+	Waitlist: &objectbox.RelationToMany{
+		Id:     10,
+		Source: &DraftBinding.Entity,
+		Target: &WaitlistEntryBinding.Entity,
+	},
 }
 
 // GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
@@ -451,10 +541,27 @@ func (draft_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.PropertyIndex(15, 722712850427169033)
 	model.Property("PickTwo", 1, 6, 5211646500085160914)
 	model.Property("Archived", 1, 7, 1041569327384204164)
-	model.EntityLastPropertyId(7, 1041569327384204164)
+	model.PropertyFlags(8)
+	model.PropertyIndex(16, 1781206014571641589)
+	model.Property("State", 9, 8, 1466617726436577215)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(17, 8413913901545310608)
+	model.Property("StartedAt", 10, 9, 481039226881069883)
+	model.Property("OpenAt", 10, 10, 1005095735305158546)
+	model.Property("StartAt", 10, 11, 4754513208561414495)
+	model.Property("ReminderSent", 1, 12, 7041047325312837011)
+	model.Property("Rounds", 6, 13, 472228001116522920)
+	model.Property("Modified", 6, 14, 5009476971943383475)
+	model.Property("UndoneModified", 6, 15, 2977680540417085463)
+	model.Property("SpectatorDelayPicks", 6, 16, 6250376400073921422)
+	model.Property("SpectatorDelaySeconds", 6, 17, 6895373476053130167)
+	model.Property("StartDelayNotified", 1, 18, 7546613106844848476)
+	model.Property("UndonePickModified", 6, 19, 4029085877171348935)
+	model.EntityLastPropertyId(19, 4029085877171348935)
 	model.Relation(1, 751382817597970823, SeatBinding.Id, SeatBinding.Uid)
 	model.Relation(2, 5954888830735860335, PackBinding.Id, PackBinding.Uid)
 	model.Relation(8, 3916323228265520547, EventBinding.Id, EventBinding.Uid)
+	model.Relation(10, 6169793726595763317, WaitlistEntryBinding.Id, WaitlistEntryBinding.Uid)
 }
 
 // GetId is called by ObjectBox during Put operations to check for existing ID on an object
@@ -482,18 +589,50 @@ func (draft_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{},
 		return err
 	}
 
+	if err := BoxForDraft(ob).RelationReplace(Draft_.Waitlist, id, object, object.(*Draft).Waitlist); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Flatten is called by ObjectBox to transform an object to a FlatBuffer
 func (draft_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
 	obj := object.(*Draft)
+	var propStartedAt int64
+	{
+		var err error
+		propStartedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.StartedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Draft.StartedAt: " + err.Error())
+		}
+	}
+
+	var propOpenAt int64
+	{
+		var err error
+		propOpenAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.OpenAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Draft.OpenAt: " + err.Error())
+		}
+	}
+
+	var propStartAt int64
+	{
+		var err error
+		propStartAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.StartAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Draft.StartAt: " + err.Error())
+		}
+	}
+
 	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
 	var offsetFormat = fbutils.CreateStringOffset(fbb, obj.Format)
 	var offsetSpectatorChannelId = fbutils.CreateStringOffset(fbb, obj.SpectatorChannelId)
+	var offsetState = fbutils.CreateStringOffset(fbb, obj.State)
 
 	// build the FlatBuffers object
-	fbb.StartObject(7)
+	fbb.StartObject(19)
 	fbutils.SetUint64Slot(fbb, 0, id)
 	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
 	fbutils.SetUOffsetTSlot(fbb, 2, offsetFormat)
@@ -501,6 +640,18 @@ func (draft_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id
 	fbutils.SetUOffsetTSlot(fbb, 4, offsetSpectatorChannelId)
 	fbutils.SetBoolSlot(fbb, 5, obj.PickTwo)
 	fbutils.SetBoolSlot(fbb, 6, obj.Archived)
+	fbutils.SetUOffsetTSlot(fbb, 7, offsetState)
+	fbutils.SetInt64Slot(fbb, 8, propStartedAt)
+	fbutils.SetInt64Slot(fbb, 9, propOpenAt)
+	fbutils.SetInt64Slot(fbb, 10, propStartAt)
+	fbutils.SetBoolSlot(fbb, 11, obj.ReminderSent)
+	fbutils.SetBoolSlot(fbb, 17, obj.StartDelayNotified)
+	fbutils.SetInt64Slot(fbb, 12, int64(obj.Rounds))
+	fbutils.SetInt64Slot(fbb, 13, int64(obj.Modified))
+	fbutils.SetInt64Slot(fbb, 14, int64(obj.UndoneModified))
+	fbutils.SetInt64Slot(fbb, 18, int64(obj.UndonePickModified))
+	fbutils.SetInt64Slot(fbb, 15, int64(obj.SpectatorDelayPicks))
+	fbutils.SetInt64Slot(fbb, 16, int64(obj.SpectatorDelaySeconds))
 	return nil
 }
 
@@ -517,6 +668,21 @@ func (draft_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}
 
 	var propId = table.GetUint64Slot(4, 0)
 
+	propStartedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 20))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Draft.StartedAt: " + err.Error())
+	}
+
+	propOpenAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 22))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Draft.OpenAt: " + err.Error())
+	}
+
+	propStartAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 24))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Draft.StartAt: " + err.Error())
+	}
+
 	var relSeats []*Seat
 	if rIds, err := BoxForDraft(ob).RelationIds(Draft_.Seats, propId); err != nil {
 		return nil, err
@@ -544,17 +710,39 @@ func (draft_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}
 		relEvents = rSlice
 	}
 
+	var relWaitlist []*WaitlistEntry
+	if rIds, err := BoxForDraft(ob).RelationIds(Draft_.Waitlist, propId); err != nil {
+		return nil, err
+	} else if rSlice, err := BoxForWaitlistEntry(ob).GetManyExisting(rIds...); err != nil {
+		return nil, err
+	} else {
+		relWaitlist = rSlice
+	}
+
 	return &Draft{
-		Id:                 propId,
-		Name:               fbutils.GetStringSlot(table, 6),
-		Format:             fbutils.GetStringSlot(table, 8),
-		InPerson:           fbutils.GetBoolSlot(table, 10),
-		Seats:              relSeats,
-		UnassignedPacks:    relUnassignedPacks,
-		Events:             relEvents,
-		SpectatorChannelId: fbutils.GetStringSlot(table, 12),
-		PickTwo:            fbutils.GetBoolSlot(table, 14),
-		Archived:           fbutils.GetBoolSlot(table, 16),
+		Id:                    propId,
+		Name:                  fbutils.GetStringSlot(table, 6),
+		Format:                fbutils.GetStringSlot(table, 8),
+		InPerson:              fbutils.GetBoolSlot(table, 10),
+		Seats:                 relSeats,
+		UnassignedPacks:       relUnassignedPacks,
+		Events:                relEvents,
+		SpectatorChannelId:    fbutils.GetStringSlot(table, 12),
+		PickTwo:               fbutils.GetBoolSlot(table, 14),
+		Archived:              fbutils.GetBoolSlot(table, 16),
+		State:                 fbutils.GetStringSlot(table, 18),
+		StartedAt:             propStartedAt,
+		OpenAt:                propOpenAt,
+		StartAt:               propStartAt,
+		ReminderSent:          fbutils.GetBoolSlot(table, 26),
+		StartDelayNotified:    fbutils.GetBoolSlot(table, 38),
+		Waitlist:              relWaitlist,
+		Rounds:                fbutils.GetIntSlot(table, 28),
+		Modified:              fbutils.GetIntSlot(table, 30),
+		UndoneModified:        fbutils.GetIntSlot(table, 32),
+		UndonePickModified:    fbutils.GetIntSlot(table, 40),
+		SpectatorDelayPicks:   fbutils.GetIntSlot(table, 34),
+		SpectatorDelaySeconds: fbutils.GetIntSlot(table, 36),
 	}, nil
 }
 
@@ -1184,6 +1372,7 @@ var Seat_ = struct {
 	ScanSound     *objectbox.PropertyInt
 	ErrorSound    *objectbox.PropertyInt
 	ReservedUser  *objectbox.RelationToOne
+	ReservedUntil *objectbox.PropertyInt64
 	Packs         *objectbox.RelationToMany
 	OriginalPacks *objectbox.RelationToMany
 	PickedCards   *objectbox.RelationToMany
@@ -1232,6 +1421,12 @@ var Seat_ = struct {
 		},
 		Target: &UserBinding.Entity,
 	},
+	ReservedUntil: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &SeatBinding.Entity,
+		},
+	},
 	Packs: &objectbox.RelationToMany{
 		Id:     5,
 		Source: &SeatBinding.Entity,
@@ -1269,7 +1464,8 @@ func (seat_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.Property("ReservedUser", 11, 7, 2175187569463296958)
 	model.PropertyFlags(520)
 	model.PropertyRelation("User", 4, 7361461358871369732)
-	model.EntityLastPropertyId(7, 2175187569463296958)
+	model.Property("ReservedUntil", 10, 8, 7240508784014799065)
+	model.EntityLastPropertyId(8, 7240508784014799065)
 	model.Relation(5, 6696446224981877860, PackBinding.Id, PackBinding.Uid)
 	model.Relation(6, 9146694319596130362, PackBinding.Id, PackBinding.Uid)
 	model.Relation(7, 8203968657580447748, CardBinding.Id, CardBinding.Uid)
@@ -1326,6 +1522,14 @@ func (seat_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, i
 // Flatten is called by ObjectBox to transform an object to a FlatBuffer
 func (seat_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
 	obj := object.(*Seat)
+	var propReservedUntil int64
+	{
+		var err error
+		propReservedUntil, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.ReservedUntil)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Seat.ReservedUntil: " + err.Error())
+		}
+	}
 
 	var rIdUser uint64
 	if rel := obj.User; rel != nil {
@@ -1346,7 +1550,7 @@ func (seat_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id
 	}
 
 	// build the FlatBuffers object
-	fbb.StartObject(7)
+	fbb.StartObject(8)
 	fbutils.SetUint64Slot(fbb, 0, id)
 	fbutils.SetInt64Slot(fbb, 1, int64(obj.Position))
 	if obj.User != nil {
@@ -1358,6 +1562,7 @@ func (seat_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id
 	fbutils.SetInt64Slot(fbb, 4, int64(obj.ScanSound))
 	fbutils.SetInt64Slot(fbb, 5, int64(obj.ErrorSound))
 	fbutils.SetInt64Slot(fbb, 3, int64(obj.Round))
+	fbutils.SetInt64Slot(fbb, 7, propReservedUntil)
 	return nil
 }
 
@@ -1374,6 +1579,11 @@ func (seat_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{},
 
 	var propId = table.GetUint64Slot(4, 0)
 
+	propReservedUntil, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 18))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Seat.ReservedUntil: " + err.Error())
+	}
+
 	var relUser *User
 	if rId := fbutils.GetUint64PtrSlot(table, 8); rId != nil && *rId > 0 {
 		if rObject, err := BoxForUser(ob).Get(*rId); err != nil {
@@ -1430,6 +1640,7 @@ func (seat_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{},
 		Packs:         relPacks,
 		OriginalPacks: relOriginalPacks,
 		PickedCards:   relPickedCards,
+		ReservedUntil: propReservedUntil,
 	}, nil
 }
 
@@ -1689,6 +1900,7 @@ var User_ = struct {
 	Picture     *objectbox.PropertyString
 	MtgoName    *objectbox.PropertyString
 	Skips       *objectbox.RelationToMany
+	FormatPrefs *objectbox.RelationToMany
 }{
 	Id: &objectbox.PropertyUint64{
 		BaseProperty: &objectbox.BaseProperty{
@@ -1725,6 +1937,11 @@ var User_ = struct {
 		Source: &UserBinding.Entity,
 		Target: &SkipBinding.Entity,
 	},
+	FormatPrefs: &objectbox.RelationToMany{
+		Id:     11,
+		Source: &UserBinding.Entity,
+		Target: &FormatPrefBinding.Entity,
+	},
 }
 
 // GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
@@ -1745,6 +1962,7 @@ func (user_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.Property("MtgoName", 9, 5, 7487591356744068798)
 	model.EntityLastPropertyId(5, 7487591356744068798)
 	model.Relation(9, 6610520635914740722, SkipBinding.Id, SkipBinding.Uid)
+	model.Relation(11, 3545478412912601664, FormatPrefBinding.Id, FormatPrefBinding.Uid)
 }
 
 // GetId is called by ObjectBox during Put operations to check for existing ID on an object
@@ -1764,6 +1982,10 @@ func (user_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, i
 		return err
 	}
 
+	if err := BoxForUser(ob).RelationReplace(User_.FormatPrefs, id, object, object.(*User).FormatPrefs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1807,6 +2029,15 @@ func (user_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{},
 		relSkips = rSlice
 	}
 
+	var relFormatPrefs []*FormatPref
+	if rIds, err := BoxForUser(ob).RelationIds(User_.FormatPrefs, propId); err != nil {
+		return nil, err
+	} else if rSlice, err := BoxForFormatPref(ob).GetManyExisting(rIds...); err != nil {
+		return nil, err
+	} else {
+		relFormatPrefs = rSlice
+	}
+
 	return &User{
 		Id:          propId,
 		DiscordId:   fbutils.GetStringSlot(table, 6),
@@ -1814,6 +2045,7 @@ func (user_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{},
 		MtgoName:    fbutils.GetStringSlot(table, 12),
 		Picture:     fbutils.GetStringSlot(table, 10),
 		Skips:       relSkips,
+		FormatPrefs: relFormatPrefs,
 	}, nil
 }
 
@@ -2075,6 +2307,7 @@ var Event_ = struct {
 	Card1        *objectbox.RelationToOne
 	Card2        *objectbox.RelationToOne
 	Pack         *objectbox.RelationToOne
+	Timestamp    *objectbox.PropertyInt64
 }{
 	Id: &objectbox.PropertyUint64{
 		BaseProperty: &objectbox.BaseProperty{
@@ -2127,6 +2360,12 @@ var Event_ = struct {
 		},
 		Target: &PackBinding.Entity,
 	},
+	Timestamp: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     27,
+			Entity: &EventBinding.Entity,
+		},
+	},
 }
 
 // GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
@@ -2152,7 +2391,8 @@ func (event_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.Property("Pack", 11, 26, 8224660085647238639)
 	model.PropertyFlags(520)
 	model.PropertyRelation("Pack", 7, 6451483386392342396)
-	model.EntityLastPropertyId(26, 8224660085647238639)
+	model.Property("Timestamp", 10, 27, 9019194966152901254)
+	model.EntityLastPropertyId(27, 9019194966152901254)
 }
 
 // GetId is called by ObjectBox during Put operations to check for existing ID on an object
@@ -2204,6 +2444,15 @@ func (event_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{},
 // Flatten is called by ObjectBox to transform an object to a FlatBuffer
 func (event_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
 	obj := object.(*Event)
+	var propTimestamp int64
+	{
+		var err error
+		propTimestamp, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.Timestamp)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Event.Timestamp: " + err.Error())
+		}
+	}
+
 	var offsetAnnouncement = fbutils.CreateStringOffset(fbb, obj.Announcement)
 
 	var rIdCard1 uint64
@@ -2234,7 +2483,7 @@ func (event_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id
 	}
 
 	// build the FlatBuffers object
-	fbb.StartObject(26)
+	fbb.StartObject(27)
 	fbutils.SetUint64Slot(fbb, 0, id)
 	fbutils.SetInt64Slot(fbb, 1, int64(obj.Position))
 	fbutils.SetUOffsetTSlot(fbb, 2, offsetAnnouncement)
@@ -2249,6 +2498,7 @@ func (event_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id
 	}
 	fbutils.SetInt64Slot(fbb, 9, int64(obj.Modified))
 	fbutils.SetInt64Slot(fbb, 10, int64(obj.Round))
+	fbutils.SetInt64Slot(fbb, 26, propTimestamp)
 	return nil
 }
 
@@ -2265,6 +2515,11 @@ func (event_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}
 
 	var propId = table.GetUint64Slot(4, 0)
 
+	propTimestamp, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 56))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Event.Timestamp: " + err.Error())
+	}
+
 	var relCard1 *Card
 	if rId := fbutils.GetUint64PtrSlot(table, 50); rId != nil && *rId > 0 {
 		if rObject, err := BoxForCard(ob).Get(*rId); err != nil {
@@ -2301,6 +2556,7 @@ func (event_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}
 		Pack:         relPack,
 		Modified:     fbutils.GetIntSlot(table, 22),
 		Round:        fbutils.GetIntSlot(table, 24),
+		Timestamp:    propTimestamp,
 	}, nil
 }
 
@@ -3617,12 +3873,15 @@ var ResultBinding = result_EntityInfo{
 
 // Result_ contains type-based Property helpers to facilitate some common operations such as Queries.
 var Result_ = struct {
-	Id        *objectbox.PropertyUint64
-	Draft     *objectbox.RelationToOne
-	Round     *objectbox.PropertyInt
-	User      *objectbox.RelationToOne
-	Win       *objectbox.PropertyBool
-	Timestamp *objectbox.PropertyInt64
+	Id         *objectbox.PropertyUint64
+	Draft      *objectbox.RelationToOne
+	Round      *objectbox.PropertyInt
+	User       *objectbox.RelationToOne
+	Win        *objectbox.PropertyBool
+	Timestamp  *objectbox.PropertyInt64
+	GamesWon   *objectbox.PropertyInt
+	GamesLost  *objectbox.PropertyInt
+	GamesDrawn *objectbox.PropertyInt
 }{
 	Id: &objectbox.PropertyUint64{
 		BaseProperty: &objectbox.BaseProperty{
@@ -3662,6 +3921,24 @@ var Result_ = struct {
 			Entity: &ResultBinding.Entity,
 		},
 	},
+	GamesWon: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &ResultBinding.Entity,
+		},
+	},
+	GamesLost: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &ResultBinding.Entity,
+		},
+	},
+	GamesDrawn: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &ResultBinding.Entity,
+		},
+	},
 }
 
 // GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
@@ -3683,7 +3960,10 @@ func (result_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.PropertyRelation("User", 13, 388245969538914106)
 	model.Property("Win", 1, 5, 984428142389294433)
 	model.Property("Timestamp", 10, 6, 5243503137203354931)
-	model.EntityLastPropertyId(6, 5243503137203354931)
+	model.Property("GamesWon", 6, 7, 3336806118930032561)
+	model.Property("GamesLost", 6, 8, 5165093700393625721)
+	model.Property("GamesDrawn", 6, 9, 7711419092995830605)
+	model.EntityLastPropertyId(9, 7711419092995830605)
 }
 
 // GetId is called by ObjectBox during Put operations to check for existing ID on an object
@@ -3753,7 +4033,7 @@ func (result_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, i
 	}
 
 	// build the FlatBuffers object
-	fbb.StartObject(6)
+	fbb.StartObject(9)
 	fbutils.SetUint64Slot(fbb, 0, id)
 	if obj.Draft != nil {
 		fbutils.SetUint64Slot(fbb, 1, rIdDraft)
@@ -3763,6 +4043,9 @@ func (result_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, i
 		fbutils.SetUint64Slot(fbb, 3, rIdUser)
 	}
 	fbutils.SetBoolSlot(fbb, 4, obj.Win)
+	fbutils.SetInt64Slot(fbb, 6, int64(obj.GamesWon))
+	fbutils.SetInt64Slot(fbb, 7, int64(obj.GamesLost))
+	fbutils.SetInt64Slot(fbb, 8, int64(obj.GamesDrawn))
 	fbutils.SetInt64Slot(fbb, 5, propTimestamp)
 	return nil
 }
@@ -3804,12 +4087,15 @@ func (result_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{
 	}
 
 	return &Result{
-		Id:        propId,
-		Draft:     relDraft,
-		Round:     fbutils.GetIntSlot(table, 8),
-		User:      relUser,
-		Win:       fbutils.GetBoolSlot(table, 12),
-		Timestamp: propTimestamp,
+		Id:         propId,
+		Draft:      relDraft,
+		Round:      fbutils.GetIntSlot(table, 8),
+		User:       relUser,
+		Win:        fbutils.GetBoolSlot(table, 12),
+		GamesWon:   fbutils.GetIntSlot(table, 16),
+		GamesLost:  fbutils.GetIntSlot(table, 18),
+		GamesDrawn: fbutils.GetIntSlot(table, 20),
+		Timestamp:  propTimestamp,
 	}, nil
 }
 
@@ -4048,3 +4334,2127 @@ func (query *ResultQuery) Limit(limit uint64) *ResultQuery {
 	query.Query.Limit(limit)
 	return query
 }
+
+type waitlistEntry_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var WaitlistEntryBinding = waitlistEntry_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 11,
+	},
+	Uid: 7135713181603929459,
+}
+
+// WaitlistEntry_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var WaitlistEntry_ = struct {
+	Id        *objectbox.PropertyUint64
+	User      *objectbox.RelationToOne
+	Timestamp *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &WaitlistEntryBinding.Entity,
+		},
+	},
+	User: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &WaitlistEntryBinding.Entity,
+		},
+		Target: &UserBinding.Entity,
+	},
+	Timestamp: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &WaitlistEntryBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (waitlistEntry_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (waitlistEntry_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("WaitlistEntry", 11, 7135713181603929459)
+	model.Property("Id", 6, 1, 5218850524960812723)
+	model.PropertyFlags(1)
+	model.Property("User", 11, 2, 2501398348249685012)
+	model.PropertyFlags(520)
+	model.PropertyRelation("User", 18, 6908926892967302209)
+	model.Property("Timestamp", 10, 3, 6414358397311221067)
+	model.EntityLastPropertyId(3, 6414358397311221067)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (waitlistEntry_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*WaitlistEntry).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (waitlistEntry_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*WaitlistEntry).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (waitlistEntry_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	if rel := object.(*WaitlistEntry).User; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForUser(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (waitlistEntry_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*WaitlistEntry)
+	var propTimestamp int64
+	{
+		var err error
+		propTimestamp, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.Timestamp)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WaitlistEntry.Timestamp: " + err.Error())
+		}
+	}
+
+	var rIdUser uint64
+	if rel := obj.User; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdUser = rId
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	if obj.User != nil {
+		fbutils.SetUint64Slot(fbb, 1, rIdUser)
+	}
+	fbutils.SetInt64Slot(fbb, 2, propTimestamp)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (waitlistEntry_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'WaitlistEntry' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propTimestamp, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 8))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WaitlistEntry.Timestamp: " + err.Error())
+	}
+
+	var relUser *User
+	if rId := fbutils.GetUint64PtrSlot(table, 6); rId != nil && *rId > 0 {
+		if rObject, err := BoxForUser(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relUser = rObject
+		}
+	}
+
+	return &WaitlistEntry{
+		Id:        propId,
+		User:      relUser,
+		Timestamp: propTimestamp,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (waitlistEntry_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*WaitlistEntry, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (waitlistEntry_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*WaitlistEntry), nil)
+	}
+	return append(slice.([]*WaitlistEntry), object.(*WaitlistEntry))
+}
+
+// Box provides CRUD access to WaitlistEntry objects
+type WaitlistEntryBox struct {
+	*objectbox.Box
+}
+
+// BoxForWaitlistEntry opens a box of WaitlistEntry objects
+func BoxForWaitlistEntry(ob *objectbox.ObjectBox) *WaitlistEntryBox {
+	return &WaitlistEntryBox{
+		Box: ob.InternalBox(11),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WaitlistEntry.Id property on the passed object will be assigned the new ID as well.
+func (box *WaitlistEntryBox) Put(object *WaitlistEntry) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WaitlistEntry.Id property on the passed object will be assigned the new ID as well.
+func (box *WaitlistEntryBox) Insert(object *WaitlistEntry) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *WaitlistEntryBox) Update(object *WaitlistEntry) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *WaitlistEntryBox) PutAsync(object *WaitlistEntry) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the WaitlistEntry.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the WaitlistEntry.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *WaitlistEntryBox) PutMany(objects []*WaitlistEntry) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *WaitlistEntryBox) Get(id uint64) (*WaitlistEntry, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*WaitlistEntry), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *WaitlistEntryBox) GetMany(ids ...uint64) ([]*WaitlistEntry, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WaitlistEntry), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *WaitlistEntryBox) GetManyExisting(ids ...uint64) ([]*WaitlistEntry, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WaitlistEntry), nil
+}
+
+// GetAll reads all stored objects
+func (box *WaitlistEntryBox) GetAll() ([]*WaitlistEntry, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WaitlistEntry), nil
+}
+
+// Remove deletes a single object
+func (box *WaitlistEntryBox) Remove(object *WaitlistEntry) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *WaitlistEntryBox) RemoveMany(objects ...*WaitlistEntry) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the WaitlistEntry_ struct to create conditions.
+// Keep the *WaitlistEntryQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *WaitlistEntryBox) Query(conditions ...objectbox.Condition) *WaitlistEntryQuery {
+	return &WaitlistEntryQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the WaitlistEntry_ struct to create conditions.
+// Keep the *WaitlistEntryQuery if you intend to execute the query multiple times.
+func (box *WaitlistEntryBox) QueryOrError(conditions ...objectbox.Condition) (*WaitlistEntryQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &WaitlistEntryQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See WaitlistEntryAsyncBox for more information.
+func (box *WaitlistEntryBox) Async() *WaitlistEntryAsyncBox {
+	return &WaitlistEntryAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// WaitlistEntryAsyncBox provides asynchronous operations on WaitlistEntry objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type WaitlistEntryAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForWaitlistEntry creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use WaitlistEntryBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForWaitlistEntry(ob *objectbox.ObjectBox, timeoutMs uint64) *WaitlistEntryAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 11, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 11: %s" + err.Error())
+	}
+	return &WaitlistEntryAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *WaitlistEntryAsyncBox) Put(object *WaitlistEntry) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *WaitlistEntryAsyncBox) Insert(object *WaitlistEntry) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *WaitlistEntryAsyncBox) Update(object *WaitlistEntry) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *WaitlistEntryAsyncBox) Remove(object *WaitlistEntry) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all WaitlistEntry which Id is either 42 or 47:
+//
+// box.Query(WaitlistEntry_.Id.In(42, 47)).Find()
+type WaitlistEntryQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *WaitlistEntryQuery) Find() ([]*WaitlistEntry, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WaitlistEntry), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *WaitlistEntryQuery) Offset(offset uint64) *WaitlistEntryQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *WaitlistEntryQuery) Limit(limit uint64) *WaitlistEntryQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type formatPref_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var FormatPrefBinding = formatPref_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 12,
+	},
+	Uid: 1046950122933188785,
+}
+
+// FormatPref_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var FormatPref_ = struct {
+	Id     *objectbox.PropertyUint64
+	Format *objectbox.PropertyString
+	Elig   *objectbox.PropertyBool
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &FormatPrefBinding.Entity,
+		},
+	},
+	Format: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &FormatPrefBinding.Entity,
+		},
+	},
+	Elig: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &FormatPrefBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (formatPref_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (formatPref_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("FormatPref", 12, 1046950122933188785)
+	model.Property("Id", 6, 1, 1239649602716801941)
+	model.PropertyFlags(1)
+	model.Property("Format", 9, 2, 4552377108093681317)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(19, 2354266428153929622)
+	model.Property("Elig", 1, 3, 1612358628077004123)
+	model.EntityLastPropertyId(3, 1612358628077004123)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (formatPref_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*FormatPref).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (formatPref_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*FormatPref).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (formatPref_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (formatPref_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*FormatPref)
+	var offsetFormat = fbutils.CreateStringOffset(fbb, obj.Format)
+
+	// build the FlatBuffers object
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetFormat)
+	fbutils.SetBoolSlot(fbb, 2, obj.Elig)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (formatPref_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'FormatPref' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	return &FormatPref{
+		Id:     propId,
+		Format: fbutils.GetStringSlot(table, 6),
+		Elig:   fbutils.GetBoolSlot(table, 8),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (formatPref_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*FormatPref, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (formatPref_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*FormatPref), nil)
+	}
+	return append(slice.([]*FormatPref), object.(*FormatPref))
+}
+
+// Box provides CRUD access to FormatPref objects
+type FormatPrefBox struct {
+	*objectbox.Box
+}
+
+// BoxForFormatPref opens a box of FormatPref objects
+func BoxForFormatPref(ob *objectbox.ObjectBox) *FormatPrefBox {
+	return &FormatPrefBox{
+		Box: ob.InternalBox(12),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the FormatPref.Id property on the passed object will be assigned the new ID as well.
+func (box *FormatPrefBox) Put(object *FormatPref) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the FormatPref.Id property on the passed object will be assigned the new ID as well.
+func (box *FormatPrefBox) Insert(object *FormatPref) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *FormatPrefBox) Update(object *FormatPref) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *FormatPrefBox) PutAsync(object *FormatPref) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the FormatPref.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the FormatPref.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *FormatPrefBox) PutMany(objects []*FormatPref) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *FormatPrefBox) Get(id uint64) (*FormatPref, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*FormatPref), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *FormatPrefBox) GetMany(ids ...uint64) ([]*FormatPref, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*FormatPref), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *FormatPrefBox) GetManyExisting(ids ...uint64) ([]*FormatPref, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*FormatPref), nil
+}
+
+// GetAll reads all stored objects
+func (box *FormatPrefBox) GetAll() ([]*FormatPref, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*FormatPref), nil
+}
+
+// Remove deletes a single object
+func (box *FormatPrefBox) Remove(object *FormatPref) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *FormatPrefBox) RemoveMany(objects ...*FormatPref) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the FormatPref_ struct to create conditions.
+// Keep the *FormatPrefQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *FormatPrefBox) Query(conditions ...objectbox.Condition) *FormatPrefQuery {
+	return &FormatPrefQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the FormatPref_ struct to create conditions.
+// Keep the *FormatPrefQuery if you intend to execute the query multiple times.
+func (box *FormatPrefBox) QueryOrError(conditions ...objectbox.Condition) (*FormatPrefQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &FormatPrefQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See FormatPrefAsyncBox for more information.
+func (box *FormatPrefBox) Async() *FormatPrefAsyncBox {
+	return &FormatPrefAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// FormatPrefAsyncBox provides asynchronous operations on FormatPref objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type FormatPrefAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForFormatPref creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use FormatPrefBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForFormatPref(ob *objectbox.ObjectBox, timeoutMs uint64) *FormatPrefAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 12, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 12: %s" + err.Error())
+	}
+	return &FormatPrefAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *FormatPrefAsyncBox) Put(object *FormatPref) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *FormatPrefAsyncBox) Insert(object *FormatPref) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *FormatPrefAsyncBox) Update(object *FormatPref) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *FormatPrefAsyncBox) Remove(object *FormatPref) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all FormatPref which Id is either 42 or 47:
+//
+// box.Query(FormatPref_.Id.In(42, 47)).Find()
+type FormatPrefQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *FormatPrefQuery) Find() ([]*FormatPref, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*FormatPref), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *FormatPrefQuery) Offset(offset uint64) *FormatPrefQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *FormatPrefQuery) Limit(limit uint64) *FormatPrefQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type deck_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var DeckBinding = deck_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 13,
+	},
+	Uid: 5529086464753104423,
+}
+
+// Deck_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Deck_ = struct {
+	Id        *objectbox.PropertyUint64
+	Seat      *objectbox.RelationToOne
+	Plains    *objectbox.PropertyInt
+	Islands   *objectbox.PropertyInt
+	Swamps    *objectbox.PropertyInt
+	Mountains *objectbox.PropertyInt
+	Forests   *objectbox.PropertyInt
+	Locked    *objectbox.PropertyBool
+	LockedAt  *objectbox.PropertyInt64
+	MainDeck  *objectbox.RelationToMany
+	Sideboard *objectbox.RelationToMany
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Seat: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &DeckBinding.Entity,
+		},
+		Target: &SeatBinding.Entity,
+	},
+	Plains: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Islands: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Swamps: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Mountains: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Forests: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	Locked: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	LockedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &DeckBinding.Entity,
+		},
+	},
+	MainDeck: &objectbox.RelationToMany{
+		Id:     12,
+		Source: &DeckBinding.Entity,
+		Target: &CardBinding.Entity,
+	},
+	Sideboard: &objectbox.RelationToMany{
+		Id:     13,
+		Source: &DeckBinding.Entity,
+		Target: &CardBinding.Entity,
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (deck_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (deck_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Deck", 13, 5529086464753104423)
+	model.Property("Id", 6, 1, 864226070909091987)
+	model.PropertyFlags(1)
+	model.Property("Seat", 11, 2, 874185206723914458)
+	model.PropertyFlags(520)
+	model.PropertyRelation("Seat", 20, 1354046688028114018)
+	model.Property("Plains", 6, 3, 6365131591127056833)
+	model.Property("Islands", 6, 4, 1705993744203219268)
+	model.Property("Swamps", 6, 5, 9062659831033829280)
+	model.Property("Mountains", 6, 6, 4462171629996585493)
+	model.Property("Forests", 6, 7, 7150643716336835921)
+	model.Property("Locked", 1, 8, 150668992922189385)
+	model.Property("LockedAt", 10, 9, 8526982566587738914)
+	model.EntityLastPropertyId(9, 8526982566587738914)
+	model.Relation(12, 3605336420277739515, CardBinding.Id, CardBinding.Uid)
+	model.Relation(13, 8721412134954118689, CardBinding.Id, CardBinding.Uid)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (deck_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Deck).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (deck_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Deck).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (deck_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	if rel := object.(*Deck).Seat; rel != nil {
+		if rId, err := SeatBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForSeat(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	if err := BoxForDeck(ob).RelationReplace(Deck_.MainDeck, id, object, object.(*Deck).MainDeck); err != nil {
+		return err
+	}
+
+	if err := BoxForDeck(ob).RelationReplace(Deck_.Sideboard, id, object, object.(*Deck).Sideboard); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (deck_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Deck)
+	var propLockedAt int64
+	{
+		var err error
+		propLockedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.LockedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Deck.LockedAt: " + err.Error())
+		}
+	}
+
+	var rIdSeat uint64
+	if rel := obj.Seat; rel != nil {
+		if rId, err := SeatBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdSeat = rId
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(9)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	if obj.Seat != nil {
+		fbutils.SetUint64Slot(fbb, 1, rIdSeat)
+	}
+	fbutils.SetInt64Slot(fbb, 2, int64(obj.Plains))
+	fbutils.SetInt64Slot(fbb, 3, int64(obj.Islands))
+	fbutils.SetInt64Slot(fbb, 4, int64(obj.Swamps))
+	fbutils.SetInt64Slot(fbb, 5, int64(obj.Mountains))
+	fbutils.SetInt64Slot(fbb, 6, int64(obj.Forests))
+	fbutils.SetBoolSlot(fbb, 7, obj.Locked)
+	fbutils.SetInt64Slot(fbb, 8, propLockedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (deck_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Deck' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propLockedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 20))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Deck.LockedAt: " + err.Error())
+	}
+
+	var relSeat *Seat
+	if rId := fbutils.GetUint64PtrSlot(table, 6); rId != nil && *rId > 0 {
+		if rObject, err := BoxForSeat(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relSeat = rObject
+		}
+	}
+
+	var relMainDeck []*Card
+	if rIds, err := BoxForDeck(ob).RelationIds(Deck_.MainDeck, propId); err != nil {
+		return nil, err
+	} else if rSlice, err := BoxForCard(ob).GetManyExisting(rIds...); err != nil {
+		return nil, err
+	} else {
+		relMainDeck = rSlice
+	}
+
+	var relSideboard []*Card
+	if rIds, err := BoxForDeck(ob).RelationIds(Deck_.Sideboard, propId); err != nil {
+		return nil, err
+	} else if rSlice, err := BoxForCard(ob).GetManyExisting(rIds...); err != nil {
+		return nil, err
+	} else {
+		relSideboard = rSlice
+	}
+
+	return &Deck{
+		Id:        propId,
+		Seat:      relSeat,
+		MainDeck:  relMainDeck,
+		Sideboard: relSideboard,
+		Plains:    fbutils.GetIntSlot(table, 8),
+		Islands:   fbutils.GetIntSlot(table, 10),
+		Swamps:    fbutils.GetIntSlot(table, 12),
+		Mountains: fbutils.GetIntSlot(table, 14),
+		Forests:   fbutils.GetIntSlot(table, 16),
+		Locked:    fbutils.GetBoolSlot(table, 18),
+		LockedAt:  propLockedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (deck_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Deck, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (deck_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Deck), nil)
+	}
+	return append(slice.([]*Deck), object.(*Deck))
+}
+
+// Box provides CRUD access to Deck objects
+type DeckBox struct {
+	*objectbox.Box
+}
+
+// BoxForDeck opens a box of Deck objects
+func BoxForDeck(ob *objectbox.ObjectBox) *DeckBox {
+	return &DeckBox{
+		Box: ob.InternalBox(13),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Deck.Id property on the passed object will be assigned the new ID as well.
+func (box *DeckBox) Put(object *Deck) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Deck.Id property on the passed object will be assigned the new ID as well.
+func (box *DeckBox) Insert(object *Deck) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *DeckBox) Update(object *Deck) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *DeckBox) PutAsync(object *Deck) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Deck.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Deck.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *DeckBox) PutMany(objects []*Deck) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *DeckBox) Get(id uint64) (*Deck, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Deck), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *DeckBox) GetMany(ids ...uint64) ([]*Deck, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Deck), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *DeckBox) GetManyExisting(ids ...uint64) ([]*Deck, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Deck), nil
+}
+
+// GetAll reads all stored objects
+func (box *DeckBox) GetAll() ([]*Deck, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Deck), nil
+}
+
+// Remove deletes a single object
+func (box *DeckBox) Remove(object *Deck) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *DeckBox) RemoveMany(objects ...*Deck) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Deck_ struct to create conditions.
+// Keep the *DeckQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *DeckBox) Query(conditions ...objectbox.Condition) *DeckQuery {
+	return &DeckQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Deck_ struct to create conditions.
+// Keep the *DeckQuery if you intend to execute the query multiple times.
+func (box *DeckBox) QueryOrError(conditions ...objectbox.Condition) (*DeckQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &DeckQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See DeckAsyncBox for more information.
+func (box *DeckBox) Async() *DeckAsyncBox {
+	return &DeckAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// DeckAsyncBox provides asynchronous operations on Deck objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type DeckAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForDeck creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use DeckBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForDeck(ob *objectbox.ObjectBox, timeoutMs uint64) *DeckAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 13, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 13: %s" + err.Error())
+	}
+	return &DeckAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *DeckAsyncBox) Put(object *Deck) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *DeckAsyncBox) Insert(object *Deck) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *DeckAsyncBox) Update(object *Deck) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *DeckAsyncBox) Remove(object *Deck) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Deck which Id is either 42 or 47:
+//
+// box.Query(Deck_.Id.In(42, 47)).Find()
+type DeckQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *DeckQuery) Find() ([]*Deck, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Deck), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *DeckQuery) Offset(offset uint64) *DeckQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *DeckQuery) Limit(limit uint64) *DeckQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type pairing_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var PairingBinding = pairing_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 14,
+	},
+	Uid: 383785429463648797,
+}
+
+// Pairing_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Pairing_ = struct {
+	Id         *objectbox.PropertyUint64
+	Draft      *objectbox.RelationToOne
+	Round      *objectbox.PropertyInt
+	Player1    *objectbox.RelationToOne
+	Player2    *objectbox.RelationToOne
+	Status     *objectbox.PropertyString
+	ReportedBy *objectbox.RelationToOne
+	Wins1      *objectbox.PropertyInt
+	Wins2      *objectbox.PropertyInt
+	Draws      *objectbox.PropertyInt
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+	Draft: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &PairingBinding.Entity,
+		},
+		Target: &DraftBinding.Entity,
+	},
+	Round: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+	Player1: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &PairingBinding.Entity,
+		},
+		Target: &UserBinding.Entity,
+	},
+	Player2: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &PairingBinding.Entity,
+		},
+		Target: &UserBinding.Entity,
+	},
+	Status: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+	ReportedBy: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &PairingBinding.Entity,
+		},
+		Target: &UserBinding.Entity,
+	},
+	Wins1: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+	Wins2: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+	Draws: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &PairingBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (pairing_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (pairing_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Pairing", 14, 383785429463648797)
+	model.Property("Id", 6, 1, 3794999425483934476)
+	model.PropertyFlags(1)
+	model.Property("Draft", 11, 2, 623379453535369169)
+	model.PropertyFlags(520)
+	model.PropertyRelation("Draft", 21, 2638754308645810929)
+	model.Property("Round", 6, 3, 6473623290165603312)
+	model.Property("Player1", 11, 4, 969690257473731638)
+	model.PropertyFlags(520)
+	model.PropertyRelation("User", 22, 6509491594893572318)
+	model.Property("Player2", 11, 5, 4825834874659139946)
+	model.PropertyFlags(520)
+	model.PropertyRelation("User", 23, 7159978546710980049)
+	model.Property("Status", 9, 6, 7761234429311373386)
+	model.Property("ReportedBy", 11, 7, 3077991334024829903)
+	model.PropertyFlags(520)
+	model.PropertyRelation("User", 24, 274588263038643780)
+	model.Property("Wins1", 6, 9, 4882780209794314237)
+	model.Property("Wins2", 6, 10, 4064821315110485598)
+	model.Property("Draws", 6, 11, 5270708367033792692)
+	model.EntityLastPropertyId(11, 5270708367033792692)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (pairing_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Pairing).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (pairing_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Pairing).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (pairing_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	if rel := object.(*Pairing).Draft; rel != nil {
+		if rId, err := DraftBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForDraft(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	if rel := object.(*Pairing).Player1; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForUser(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	if rel := object.(*Pairing).Player2; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForUser(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	if rel := object.(*Pairing).ReportedBy; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else if rId == 0 {
+			// NOTE Put/PutAsync() has a side-effect of setting the rel.ID
+			if _, err := BoxForUser(ob).Put(rel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (pairing_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Pairing)
+	var offsetStatus = fbutils.CreateStringOffset(fbb, obj.Status)
+
+	var rIdDraft uint64
+	if rel := obj.Draft; rel != nil {
+		if rId, err := DraftBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdDraft = rId
+		}
+	}
+
+	var rIdPlayer1 uint64
+	if rel := obj.Player1; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdPlayer1 = rId
+		}
+	}
+
+	var rIdPlayer2 uint64
+	if rel := obj.Player2; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdPlayer2 = rId
+		}
+	}
+
+	var rIdReportedBy uint64
+	if rel := obj.ReportedBy; rel != nil {
+		if rId, err := UserBinding.GetId(rel); err != nil {
+			return err
+		} else {
+			rIdReportedBy = rId
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	if obj.Draft != nil {
+		fbutils.SetUint64Slot(fbb, 1, rIdDraft)
+	}
+	fbutils.SetInt64Slot(fbb, 2, int64(obj.Round))
+	if obj.Player1 != nil {
+		fbutils.SetUint64Slot(fbb, 3, rIdPlayer1)
+	}
+	if obj.Player2 != nil {
+		fbutils.SetUint64Slot(fbb, 4, rIdPlayer2)
+	}
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetStatus)
+	if obj.ReportedBy != nil {
+		fbutils.SetUint64Slot(fbb, 6, rIdReportedBy)
+	}
+	fbutils.SetInt64Slot(fbb, 8, int64(obj.Wins1))
+	fbutils.SetInt64Slot(fbb, 9, int64(obj.Wins2))
+	fbutils.SetInt64Slot(fbb, 10, int64(obj.Draws))
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (pairing_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Pairing' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	var relDraft *Draft
+	if rId := fbutils.GetUint64PtrSlot(table, 6); rId != nil && *rId > 0 {
+		if rObject, err := BoxForDraft(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relDraft = rObject
+		}
+	}
+
+	var relPlayer1 *User
+	if rId := fbutils.GetUint64PtrSlot(table, 10); rId != nil && *rId > 0 {
+		if rObject, err := BoxForUser(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relPlayer1 = rObject
+		}
+	}
+
+	var relPlayer2 *User
+	if rId := fbutils.GetUint64PtrSlot(table, 12); rId != nil && *rId > 0 {
+		if rObject, err := BoxForUser(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relPlayer2 = rObject
+		}
+	}
+
+	var relReportedBy *User
+	if rId := fbutils.GetUint64PtrSlot(table, 16); rId != nil && *rId > 0 {
+		if rObject, err := BoxForUser(ob).Get(*rId); err != nil {
+			return nil, err
+		} else {
+			relReportedBy = rObject
+		}
+	}
+
+	return &Pairing{
+		Id:         propId,
+		Draft:      relDraft,
+		Round:      fbutils.GetIntSlot(table, 8),
+		Player1:    relPlayer1,
+		Player2:    relPlayer2,
+		Status:     fbutils.GetStringSlot(table, 14),
+		ReportedBy: relReportedBy,
+		Wins1:      fbutils.GetIntSlot(table, 20),
+		Wins2:      fbutils.GetIntSlot(table, 22),
+		Draws:      fbutils.GetIntSlot(table, 24),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (pairing_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Pairing, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (pairing_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Pairing), nil)
+	}
+	return append(slice.([]*Pairing), object.(*Pairing))
+}
+
+// Box provides CRUD access to Pairing objects
+type PairingBox struct {
+	*objectbox.Box
+}
+
+// BoxForPairing opens a box of Pairing objects
+func BoxForPairing(ob *objectbox.ObjectBox) *PairingBox {
+	return &PairingBox{
+		Box: ob.InternalBox(14),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Pairing.Id property on the passed object will be assigned the new ID as well.
+func (box *PairingBox) Put(object *Pairing) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Pairing.Id property on the passed object will be assigned the new ID as well.
+func (box *PairingBox) Insert(object *Pairing) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *PairingBox) Update(object *Pairing) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *PairingBox) PutAsync(object *Pairing) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Pairing.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Pairing.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *PairingBox) PutMany(objects []*Pairing) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *PairingBox) Get(id uint64) (*Pairing, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Pairing), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *PairingBox) GetMany(ids ...uint64) ([]*Pairing, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Pairing), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *PairingBox) GetManyExisting(ids ...uint64) ([]*Pairing, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Pairing), nil
+}
+
+// GetAll reads all stored objects
+func (box *PairingBox) GetAll() ([]*Pairing, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Pairing), nil
+}
+
+// Remove deletes a single object
+func (box *PairingBox) Remove(object *Pairing) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *PairingBox) RemoveMany(objects ...*Pairing) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Pairing_ struct to create conditions.
+// Keep the *PairingQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *PairingBox) Query(conditions ...objectbox.Condition) *PairingQuery {
+	return &PairingQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Pairing_ struct to create conditions.
+// Keep the *PairingQuery if you intend to execute the query multiple times.
+func (box *PairingBox) QueryOrError(conditions ...objectbox.Condition) (*PairingQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &PairingQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See PairingAsyncBox for more information.
+func (box *PairingBox) Async() *PairingAsyncBox {
+	return &PairingAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// PairingAsyncBox provides asynchronous operations on Pairing objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type PairingAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForPairing creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use PairingBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForPairing(ob *objectbox.ObjectBox, timeoutMs uint64) *PairingAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 14, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 14: %s" + err.Error())
+	}
+	return &PairingAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *PairingAsyncBox) Put(object *Pairing) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *PairingAsyncBox) Insert(object *Pairing) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *PairingAsyncBox) Update(object *Pairing) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *PairingAsyncBox) Remove(object *Pairing) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Pairing which Id is either 42 or 47:
+//
+// box.Query(Pairing_.Id.In(42, 47)).Find()
+type PairingQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *PairingQuery) Find() ([]*Pairing, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Pairing), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *PairingQuery) Offset(offset uint64) *PairingQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *PairingQuery) Limit(limit uint64) *PairingQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type season_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var SeasonBinding = season_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 15,
+	},
+	Uid: 6968326560255471446,
+}
+
+// Season_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Season_ = struct {
+	Id      *objectbox.PropertyUint64
+	Name    *objectbox.PropertyString
+	StartAt *objectbox.PropertyInt64
+	EndAt   *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &SeasonBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &SeasonBinding.Entity,
+		},
+	},
+	StartAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &SeasonBinding.Entity,
+		},
+	},
+	EndAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &SeasonBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (season_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (season_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Season", 15, 6968326560255471446)
+	model.Property("Id", 6, 1, 327629115209635600)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 4092920687236192751)
+	model.Property("StartAt", 10, 3, 8800549193158199578)
+	model.Property("EndAt", 10, 4, 1121435454548663738)
+	model.EntityLastPropertyId(4, 1121435454548663738)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (season_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Season).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (season_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Season).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (season_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (season_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Season)
+	var propStartAt int64
+	{
+		var err error
+		propStartAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.StartAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Season.StartAt: " + err.Error())
+		}
+	}
+
+	var propEndAt int64
+	{
+		var err error
+		propEndAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.EndAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Season.EndAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+
+	// build the FlatBuffers object
+	fbb.StartObject(4)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetInt64Slot(fbb, 2, propStartAt)
+	fbutils.SetInt64Slot(fbb, 3, propEndAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (season_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Season' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propStartAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 8))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Season.StartAt: " + err.Error())
+	}
+
+	propEndAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Season.EndAt: " + err.Error())
+	}
+
+	return &Season{
+		Id:      propId,
+		Name:    fbutils.GetStringSlot(table, 6),
+		StartAt: propStartAt,
+		EndAt:   propEndAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (season_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Season, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (season_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Season), nil)
+	}
+	return append(slice.([]*Season), object.(*Season))
+}
+
+// Box provides CRUD access to Season objects
+type SeasonBox struct {
+	*objectbox.Box
+}
+
+// BoxForSeason opens a box of Season objects
+func BoxForSeason(ob *objectbox.ObjectBox) *SeasonBox {
+	return &SeasonBox{
+		Box: ob.InternalBox(15),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Season.Id property on the passed object will be assigned the new ID as well.
+func (box *SeasonBox) Put(object *Season) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Season.Id property on the passed object will be assigned the new ID as well.
+func (box *SeasonBox) Insert(object *Season) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *SeasonBox) Update(object *Season) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *SeasonBox) PutAsync(object *Season) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Season.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Season.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *SeasonBox) PutMany(objects []*Season) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *SeasonBox) Get(id uint64) (*Season, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Season), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *SeasonBox) GetMany(ids ...uint64) ([]*Season, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Season), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *SeasonBox) GetManyExisting(ids ...uint64) ([]*Season, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Season), nil
+}
+
+// GetAll reads all stored objects
+func (box *SeasonBox) GetAll() ([]*Season, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Season), nil
+}
+
+// Remove deletes a single object
+func (box *SeasonBox) Remove(object *Season) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *SeasonBox) RemoveMany(objects ...*Season) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Season_ struct to create conditions.
+// Keep the *SeasonQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *SeasonBox) Query(conditions ...objectbox.Condition) *SeasonQuery {
+	return &SeasonQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Season_ struct to create conditions.
+// Keep the *SeasonQuery if you intend to execute the query multiple times.
+func (box *SeasonBox) QueryOrError(conditions ...objectbox.Condition) (*SeasonQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &SeasonQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See SeasonAsyncBox for more information.
+func (box *SeasonBox) Async() *SeasonAsyncBox {
+	return &SeasonAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// SeasonAsyncBox provides asynchronous operations on Season objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type SeasonAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForSeason creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use SeasonBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForSeason(ob *objectbox.ObjectBox, timeoutMs uint64) *SeasonAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 15, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 15: %s" + err.Error())
+	}
+	return &SeasonAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *SeasonAsyncBox) Put(object *Season) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *SeasonAsyncBox) Insert(object *Season) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *SeasonAsyncBox) Update(object *Season) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *SeasonAsyncBox) Remove(object *Season) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Season which Id is either 42 or 47:
+//
+// box.Query(Season_.Id.In(42, 47)).Find()
+type SeasonQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *SeasonQuery) Find() ([]*Season, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Season), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *SeasonQuery) Offset(offset uint64) *SeasonQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *SeasonQuery) Limit(limit uint64) *SeasonQuery {
+	query.Query.Limit(limit)
+	return query
+}
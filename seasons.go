@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/ratings"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// leaderboardAnnouncementSize is how many players the Discord leaderboard announcement lists.
+const leaderboardAnnouncementSize = 8
+
+// ServeAPISeasons serves the /api/seasons endpoint, which lists every season.
+func ServeAPISeasons(w http.ResponseWriter, _ *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	return writeSeasons(w, ob)
+}
+
+// ServeAPIAddSeason serves the /api/addseason endpoint, which lets the admin add a season.
+// It responds with every season, like /api/seasons.
+func ServeAPIAddSeason(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if r.Method != "POST" {
+		return MethodNotAllowedError
+	}
+	if userID != 1 {
+		return fmt.Errorf("not allowed")
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading post body: %w", err)
+	}
+	var posted PostedSeason
+	err = json.Unmarshal(bodyBytes, &posted)
+	if err != nil {
+		return fmt.Errorf("error parsing post body: %w", err)
+	}
+	err = addSeason(ob, posted)
+	if err != nil {
+		return err
+	}
+	return writeSeasons(w, ob)
+}
+
+func writeSeasons(w http.ResponseWriter, ob *objectbox.ObjectBox) error {
+	seasons, err := getSeasons(ob)
+	if err != nil {
+		return err
+	}
+	seasonsJSON := []SeasonJSON{}
+	for _, season := range seasons {
+		seasonsJSON = append(seasonsJSON, seasonToJSON(season))
+	}
+	return json.NewEncoder(w).Encode(seasonsJSON)
+}
+
+func addSeason(ob *objectbox.ObjectBox, posted PostedSeason) error {
+	if len(posted.Name) == 0 {
+		return fmt.Errorf("seasons need a name")
+	}
+	season := &schema.Season{Name: posted.Name}
+	var err error
+	season.StartAt, err = time.Parse(time.RFC3339, posted.StartAt)
+	if err != nil {
+		return fmt.Errorf("bad start time: %w", err)
+	}
+	if len(posted.EndAt) > 0 {
+		season.EndAt, err = time.Parse(time.RFC3339, posted.EndAt)
+		if err != nil {
+			return fmt.Errorf("bad end time: %w", err)
+		}
+		if !season.EndAt.After(season.StartAt) {
+			return fmt.Errorf("season %q ends before it starts", posted.Name)
+		}
+	}
+	_, err = schema.BoxForSeason(ob).Put(season)
+	if err != nil {
+		return fmt.Errorf("error saving season: %w", err)
+	}
+	return nil
+}
+
+// getSeasons lists every season in the order they start.
+func getSeasons(ob *objectbox.ObjectBox) ([]*schema.Season, error) {
+	seasons, err := schema.BoxForSeason(ob).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("error loading seasons: %w", err)
+	}
+	slices.SortFunc(seasons, func(a, b *schema.Season) int {
+		return a.StartAt.Compare(b.StartAt)
+	})
+	return seasons, nil
+}
+
+// currentSeason returns the season that's underway, or if there isn't one, the last one to start.
+// It returns nil if no seasons have started.
+func currentSeason(ob *objectbox.ObjectBox) (*schema.Season, error) {
+	seasons, err := getSeasons(ob)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var current *schema.Season
+	for _, season := range seasons {
+		if season.StartAt.After(now) {
+			break
+		}
+		current = season
+		if !isTimeSet(season.EndAt) || season.EndAt.After(now) {
+			return season, nil
+		}
+	}
+	return current, nil
+}
+
+func seasonToJSON(season *schema.Season) SeasonJSON {
+	seasonJSON := SeasonJSON{
+		ID:      int64(season.Id),
+		Name:    season.Name,
+		StartAt: season.StartAt,
+	}
+	if isTimeSet(season.EndAt) {
+		seasonJSON.EndAt = &season.EndAt
+	}
+	return seasonJSON
+}
+
+// loadRatings recomputes everyone's ratings from every result ever recorded. Opponents come from
+// pairings; results from before pairings were stored are rated against the rest of their round.
+func loadRatings(ob *objectbox.ObjectBox) (ratings.Ratings, error) {
+	pairingRows, err := schema.BoxForPairing(ob).GetAll()
+	if err != nil {
+		return ratings.Ratings{}, fmt.Errorf("error loading pairings: %w", err)
+	}
+	type userRound struct {
+		draft uint64
+		round int
+		user  uint64
+	}
+	opponents := make(map[userRound]uint64)
+	byes := make(map[userRound]bool)
+	for _, pairing := range pairingRows {
+		if pairing.Draft == nil || pairing.Player1 == nil {
+			continue
+		}
+		player1 := userRound{pairing.Draft.Id, pairing.Round, pairing.Player1.Id}
+		if pairing.Player2 == nil {
+			byes[player1] = true
+			continue
+		}
+		opponents[player1] = pairing.Player2.Id
+		opponents[userRound{pairing.Draft.Id, pairing.Round, pairing.Player2.Id}] = pairing.Player1.Id
+	}
+
+	results, err := schema.BoxForResult(ob).GetAll()
+	if err != nil {
+		return ratings.Ratings{}, fmt.Errorf("error loading results: %w", err)
+	}
+	var ratingResults []ratings.Result
+	for _, result := range results {
+		if result.Draft == nil || result.User == nil {
+			continue
+		}
+		key := userRound{result.Draft.Id, result.Round, result.User.Id}
+		// Seasons go by when the draft started, so every round of a draft counts towards the same one.
+		playedAt := result.Timestamp
+		if isTimeSet(result.Draft.StartedAt) {
+			playedAt = result.Draft.StartedAt
+		}
+		ratingResults = append(ratingResults, ratings.Result{
+			Draft:      result.Draft.Id,
+			Round:      result.Round,
+			Time:       playedAt,
+			Player:     result.User.Id,
+			Opponent:   opponents[key],
+			Bye:        byes[key],
+			GamesWon:   result.GamesWon,
+			GamesLost:  result.GamesLost,
+			GamesDrawn: result.GamesDrawn,
+		})
+	}
+	return ratings.Compute(ratingResults), nil
+}
+
+// ServeAPILeaderboard serves the /api/leaderboard endpoint: /api/leaderboard/{season} ranks players by
+// their points in a season, and /api/leaderboard/ ranks them over every result.
+func ServeAPILeaderboard(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	re := regexp.MustCompile(`/api/leaderboard/(\d*)`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
+	}
+	var season *schema.Season
+	if len(parseResult[1]) > 0 {
+		seasonID, err := strconv.ParseUint(parseResult[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad api url: %w", err)
+		}
+		season, err = schema.BoxForSeason(ob).Get(seasonID)
+		if err != nil {
+			return fmt.Errorf("error loading season %d: %w", seasonID, err)
+		}
+		if season == nil {
+			return fmt.Errorf("couldn't find season %d", seasonID)
+		}
+	}
+	leaderboard, err := getLeaderboard(ob, season)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(leaderboard)
+}
+
+// getLeaderboard ranks players over a season, or over every result if season is nil.
+func getLeaderboard(ob *objectbox.ObjectBox, season *schema.Season) (Leaderboard, error) {
+	allRatings, err := loadRatings(ob)
+	if err != nil {
+		return Leaderboard{}, err
+	}
+	users, err := loadUsers(ob)
+	if err != nil {
+		return Leaderboard{}, err
+	}
+
+	leaderboard := Leaderboard{Entries: []LeaderboardEntry{}}
+	var from, to time.Time
+	if season != nil {
+		seasonJSON := seasonToJSON(season)
+		leaderboard.Season = &seasonJSON
+		from = season.StartAt
+		if isTimeSet(season.EndAt) {
+			to = season.EndAt
+		}
+	}
+	for i, standing := range ratings.Leaderboard(allRatings, from, to) {
+		user, ok := users[standing.Player]
+		if !ok {
+			continue
+		}
+		leaderboard.Entries = append(leaderboard.Entries, LeaderboardEntry{
+			Rank:   i + 1,
+			Player: makeUserInfo(user),
+			Points: standing.Points,
+			Wins:   standing.Wins,
+			Losses: standing.Losses,
+			Draws:  standing.Draws,
+			Drafts: standing.Drafts,
+			Rating: standing.Rating,
+		})
+	}
+	return leaderboard, nil
+}
+
+func loadUsers(ob *objectbox.ObjectBox) (map[uint64]*schema.User, error) {
+	userList, err := schema.BoxForUser(ob).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("error loading users: %w", err)
+	}
+	users := make(map[uint64]*schema.User)
+	for _, user := range userList {
+		users[user.Id] = user
+	}
+	return users, nil
+}
+
+// ServeAPIPlayerHistory serves the /api/playerhistory/{user} endpoint: a player's current rating and
+// every result that changed it.
+func ServeAPIPlayerHistory(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	re := regexp.MustCompile(`/api/playerhistory/(\d+)`)
+	parseResult := re.FindStringSubmatch(r.URL.Path)
+	if parseResult == nil {
+		return fmt.Errorf("bad api url")
+	}
+	playerID, err := strconv.ParseUint(parseResult[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad api url: %w", err)
+	}
+	users, err := loadUsers(ob)
+	if err != nil {
+		return err
+	}
+	player, ok := users[playerID]
+	if !ok {
+		return fmt.Errorf("couldn't find user %d", playerID)
+	}
+	allRatings, err := loadRatings(ob)
+	if err != nil {
+		return err
+	}
+
+	history := PlayerHistory{
+		Player:  makeUserInfo(player),
+		Rating:  allRatings.Rating(playerID),
+		Results: []RatedResult{},
+	}
+	for _, entry := range allRatings.History[playerID] {
+		rated := RatedResult{
+			DraftID:      int64(entry.Draft),
+			Round:        entry.Round,
+			PlayedAt:     entry.Time,
+			Bye:          entry.Bye,
+			GamesWon:     entry.GamesWon,
+			GamesLost:    entry.GamesLost,
+			GamesDrawn:   entry.GamesDrawn,
+			RatingBefore: entry.Before,
+			RatingAfter:  entry.After,
+		}
+		if opponent, ok := users[entry.Opponent]; ok {
+			opponentInfo := makeUserInfo(opponent)
+			rated.Opponent = &opponentInfo
+		}
+		history.Results = append(history.Results, rated)
+	}
+	return json.NewEncoder(w).Encode(history)
+}
+
+// AnnounceLeaderboard posts the top of the current season's leaderboard to a Discord channel.
+func AnnounceLeaderboard(ob *objectbox.ObjectBox, channelID string) error {
+	season, err := currentSeason(ob)
+	if err != nil {
+		return err
+	}
+	leaderboard, err := getLeaderboard(ob, season)
+	if err != nil {
+		return err
+	}
+	title := "All-time leaderboard"
+	if season != nil {
+		title = fmt.Sprintf("%s leaderboard", season.Name)
+	}
+	users, err := loadUsers(ob)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, entry := range leaderboard.Entries[:min(len(leaderboard.Entries), leaderboardAnnouncementSize)] {
+		lines = append(lines, fmt.Sprintf("%d. %s: %d points (%s), rated %.0f", entry.Rank,
+			discordMention(users[uint64(entry.Player.ID)]), entry.Points,
+			formatScore(entry.Wins, entry.Losses, entry.Draws), entry.Rating))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No results yet.")
+	}
+	_, err = DiscordNotifyEmbed(channelID, &discordgo.MessageEmbed{
+		Title:       title,
+		Description: strings.Join(lines, "\n"),
+		Color:       Pink,
+	})
+	return err
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// replayAccess is how much of a draft's replay a user may see.
+type replayAccess int
+
+const (
+	// filteredReplay is only what the user has seen while drafting.
+	filteredReplay replayAccess = iota
+	// delayedReplay is every pick, but only up to spectatorCutoff.
+	delayedReplay
+	// fullReplay is every pick.
+	fullReplay
+)
+
+// spectatorCutoff returns the DraftModified value of the latest pick spectators may see, so that what
+// they can tell drafters is already out of date. Every pick up to it must be at least
+// SpectatorDelayPicks picks behind the slowest drafter and SpectatorDelaySeconds old. Picks from
+// before they were timed count as old enough.
+func spectatorCutoff(draft *schema.Draft, now time.Time) int64 {
+	events := slices.Clone(draft.Events)
+	slices.SortFunc(events, func(a, b *schema.Event) int {
+		return cmp.Compare(a.Modified, b.Modified)
+	})
+
+	picks := make(map[int]int)
+	for _, event := range events {
+		picks[event.Position]++
+	}
+	slowest := -1
+	for _, seat := range draft.Seats {
+		if slowest == -1 || picks[seat.Position] < slowest {
+			slowest = picks[seat.Position]
+		}
+	}
+	maxPick := slowest - draft.SpectatorDelayPicks
+	latest := now.Add(-time.Duration(draft.SpectatorDelaySeconds) * time.Second)
+
+	var cutoff int64
+	seen := make(map[int]int)
+	for _, event := range events {
+		seen[event.Position]++
+		if seen[event.Position] > maxPick || (isTimeSet(event.Timestamp) && event.Timestamp.After(latest)) {
+			break
+		}
+		cutoff = int64(event.Modified)
+	}
+	return cutoff
+}
+
+// delayForSpectators winds a draft's replay data back to how it stood at spectatorCutoff.
+func delayForSpectators(draftJSON *DraftJSON, draft *schema.Draft, now time.Time) {
+	cutoff := spectatorCutoff(draft, now)
+	draftJSON.Modified = cutoff
+	draftJSON.Events = slices.DeleteFunc(slices.Clone(draftJSON.Events), func(event DraftEvent) bool {
+		return event.DraftModified > cutoff
+	})
+	hideUnopenedPacks(draftJSON)
+}
+
+// hideUnopenedPacks hides the cards in packs that haven't been picked from in a draft's replay data.
+// Each seat opens its own pack with its first pick of the round.
+func hideUnopenedPacks(draftJSON *DraftJSON) {
+	opened := make(map[packSlot]bool)
+	for _, event := range draftJSON.Events {
+		opened[packSlot{position: int(event.Position), round: int(event.Round) - 1}] = true
+	}
+	draftJSON.Seats = slices.Clone(draftJSON.Seats)
+	for position := range draftJSON.Seats {
+		packs := &draftJSON.Seats[position].Packs
+		for round := range packs {
+			if packs[round] == nil || opened[packSlot{position: position, round: round}] {
+				continue
+			}
+			hidden := make([]interface{}, len(packs[round]))
+			for i, card := range packs[round] {
+				hidden[i] = map[string]interface{}{
+					"id":     card.(map[string]interface{})["id"],
+					"hidden": true,
+					"scryfall": map[string]interface{}{
+						"name": "Currently Unknown Card",
+					},
+				}
+			}
+			packs[round] = hidden
+		}
+	}
+}
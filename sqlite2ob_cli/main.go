@@ -68,7 +68,8 @@ func main() {
 		}
 		for rows.Next() {
 			user := schema.User{
-				Skips: []*schema.Skip{},
+				Skips:       []*schema.Skip{},
+				FormatPrefs: []*schema.FormatPref{},
 			}
 			var mtgoName sql.NullString
 			err = rows.Scan(
@@ -260,6 +261,36 @@ func main() {
 		if err != nil {
 			return err
 		}
+		rows, err = tx.Query("select user, format, elig from userformats order by id")
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			pref := schema.FormatPref{}
+			var userId int
+			err = rows.Scan(
+				&userId,
+				&pref.Format,
+				&pref.Elig,
+			)
+			if err != nil {
+				return err
+			}
+			user, ok := users[userId]
+			if !ok {
+				continue
+			}
+			// Later rows win if a user changed their mind about a format.
+			existing := slices.IndexFunc(user.FormatPrefs, func(p *schema.FormatPref) bool {
+				return p.Format == pref.Format
+			})
+			if existing != -1 {
+				user.FormatPrefs[existing].Elig = pref.Elig
+			} else {
+				user.FormatPrefs = append(user.FormatPrefs, &pref)
+			}
+		}
+
 		_, err = userBox.PutMany(slices.Collect(maps.Values(users)))
 		if err != nil {
 			return err
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // These structs are for supplying page data to .tmpl files
 
 // VuePageData is the input to the Vue shell template.
@@ -24,6 +26,12 @@ type DraftJSON struct {
 	PickXsrf  string       `json:"pickXsrf"`
 	InPerson  bool         `json:"inPerson"`
 	PickTwo   bool         `json:"pickTwo"`
+	// Modified is the draft's latest DraftModified value. Passing it back as ?since= fetches only
+	// what changed after it.
+	Modified int64 `json:"modified"`
+	// FullRefresh is set when a ?since= request gets the whole draft back because the client's copy
+	// can't be brought up to date, for example after a pick was undone.
+	FullRefresh bool `json:"fullRefresh,omitempty"`
 }
 
 // Seat is part of DraftJSON.
@@ -35,6 +43,15 @@ type Seat struct {
 	PlayerImage string           `json:"playerImage"`
 	ScanSound   int64            `json:"scanSound"`
 	ErrorSound  int64            `json:"errorSound"`
+	Deck        *DeckJSON        `json:"deck,omitempty"`
+}
+
+// DeckJSON is a seat's deck, with cards referenced by id. It's part of Seat once the draft is finished.
+type DeckJSON struct {
+	MainDeck  []int64        `json:"mainDeck"`
+	Sideboard []int64        `json:"sideboard"`
+	Basics    map[string]int `json:"basics"`
+	Locked    bool           `json:"locked"`
 }
 
 // DraftEvent is part of DraftJSON.
@@ -47,6 +64,8 @@ type DraftEvent struct {
 	Round          int64    `json:"round"`
 	Librarian      bool     `json:"librarian"`
 	Type           string   `json:"type"`
+	// Timestamp is when the pick was made, or nil for picks from before they were timed.
+	Timestamp *time.Time `json:"timestamp"`
 }
 
 // These structs are for sending other data to the client.
@@ -70,9 +89,12 @@ type DraftListEntry struct {
 	Joined         bool   `json:"joined"`
 	Reserved       bool   `json:"reserved"`
 	Skipped        bool   `json:"skipped"`
+	Waitlisted     bool   `json:"waitlisted"`
+	WaitlistLength int64  `json:"waitlistLength"`
 	Name           string `json:"name"`
 	Status         string `json:"status"`
-	InPerson       bool   `json:"inPerson""`
+	State          string `json:"state"`
+	InPerson       bool   `json:"inPerson"`
 }
 
 // UserInfo is JSON passed to the client.
@@ -91,14 +113,261 @@ type UserFormatPrefs struct {
 // UserFormatPref is turned into JSON and used for the REST API.
 type UserFormatPref struct {
 	Format string `json:"format"`
+	Name   string `json:"name"`
 	Elig   bool   `json:"elig"`
 }
 
-// UserStats contains stats for the current user.
+// UserStats contains stats for a user. Color histograms count white, blue, black, red, green and
+// colorless cards in that order.
 type UserStats struct {
-	CompletedDrafts int   `json:"completedDrafts"`
-	ActiveDrafts    int   `json:"activeDrafts"`
-	DraftedColors   []int `json:"draftedColors"`
+	Player          UserInfo               `json:"player"`
+	CompletedDrafts int                    `json:"completedDrafts"`
+	ActiveDrafts    int                    `json:"activeDrafts"`
+	DraftedColors   []int                  `json:"draftedColors"`
+	Matches         MatchRecord            `json:"matches"`
+	MatchesByColors map[string]MatchRecord `json:"matchesByColors"`
+	MatchesByFormat map[string]MatchRecord `json:"matchesByFormat"`
+	MostPicked      []CardCount            `json:"mostPicked"`
+	RaresPicked     int                    `json:"raresPicked"`
+	// RareAveragePick is where in a pack rares and mythics were taken on average, starting at 1.
+	RareAveragePick float64          `json:"rareAveragePick"`
+	FirstPickColors []int            `json:"firstPickColors"`
+	Trophies        int              `json:"trophies"`
+	History         []UserDraftStats `json:"history"`
+}
+
+// MatchRecord is part of UserStats. Byes aren't counted.
+type MatchRecord struct {
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	Draws   int     `json:"draws"`
+	WinRate float64 `json:"winRate"`
+}
+
+// CardCount is part of UserStats.
+type CardCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// UserDraftStats is part of UserStats: how a user did in one draft. Colors are the two colors the
+// user played most, from their registered deck if they have one and otherwise from their picks.
+type UserDraftStats struct {
+	DraftID int64  `json:"draftId"`
+	Name    string `json:"name"`
+	Format  string `json:"format"`
+	State   string `json:"state"`
+	Colors  string `json:"colors"`
+	Picks   int    `json:"picks"`
+	Wins    int    `json:"wins"`
+	Losses  int    `json:"losses"`
+	Draws   int    `json:"draws"`
+	Byes    int    `json:"byes"`
+	Trophy  bool   `json:"trophy"`
+}
+
+// Standings is the Swiss standings and pairings of a draft, turned into JSON and used for the REST API.
+type Standings struct {
+	DraftID int64 `json:"draftId"`
+	// Rounds is how many rounds will be played in total.
+	Rounds       int              `json:"rounds"`
+	CurrentRound int              `json:"currentRound"`
+	Standings    []PlayerStanding `json:"standings"`
+	Pairings     []RoundPairings  `json:"pairings"`
+}
+
+// PlayerStanding is part of Standings. Percentages are between 0 and 1.
+type PlayerStanding struct {
+	Rank                int      `json:"rank"`
+	Player              UserInfo `json:"player"`
+	Points              int      `json:"points"`
+	MatchWins           int      `json:"matchWins"`
+	MatchLosses         int      `json:"matchLosses"`
+	MatchDraws          int      `json:"matchDraws"`
+	GameWins            int      `json:"gameWins"`
+	GameLosses          int      `json:"gameLosses"`
+	GameDraws           int      `json:"gameDraws"`
+	Byes                int      `json:"byes"`
+	MatchWinPct         float64  `json:"matchWinPct"`
+	GameWinPct          float64  `json:"gameWinPct"`
+	OpponentMatchWinPct float64  `json:"opponentMatchWinPct"`
+	OpponentGameWinPct  float64  `json:"opponentGameWinPct"`
+	// CurrentOpponent is who the player is paired against in the current round. It's nil if they have
+	// a bye or nothing has been paired yet.
+	CurrentOpponent *UserInfo `json:"currentOpponent"`
+}
+
+// RoundPairings is part of Standings.
+type RoundPairings struct {
+	Round   int         `json:"round"`
+	Matches []MatchJSON `json:"matches"`
+}
+
+// MatchJSON is part of RoundPairings. Player2 is nil if Player1 has a bye.
+// Reported is true once the result is confirmed; until then Status says how far reporting has got.
+type MatchJSON struct {
+	Player1    UserInfo  `json:"player1"`
+	Player2    *UserInfo `json:"player2"`
+	Wins1      int       `json:"wins1"`
+	Wins2      int       `json:"wins2"`
+	Draws      int       `json:"draws"`
+	Reported   bool      `json:"reported"`
+	Status     string    `json:"status"`
+	ReportedBy *int64    `json:"reportedBy"`
+}
+
+// SeasonJSON describes a season. EndAt is nil if the season hasn't got an end date.
+type SeasonJSON struct {
+	ID      int64      `json:"id"`
+	Name    string     `json:"name"`
+	StartAt time.Time  `json:"startAt"`
+	EndAt   *time.Time `json:"endAt"`
+}
+
+// Leaderboard is turned into JSON and used for the REST API. Season is nil for the all-time leaderboard.
+type Leaderboard struct {
+	Season  *SeasonJSON        `json:"season"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// LeaderboardEntry is part of Leaderboard.
+type LeaderboardEntry struct {
+	Rank   int      `json:"rank"`
+	Player UserInfo `json:"player"`
+	Points int      `json:"points"`
+	Wins   int      `json:"wins"`
+	Losses int      `json:"losses"`
+	Draws  int      `json:"draws"`
+	Drafts int      `json:"drafts"`
+	Rating float64  `json:"rating"`
+}
+
+// PlayerHistory is a player's rating and results, turned into JSON and used for the REST API.
+type PlayerHistory struct {
+	Player  UserInfo      `json:"player"`
+	Rating  float64       `json:"rating"`
+	Results []RatedResult `json:"results"`
+}
+
+// RatedResult is part of PlayerHistory. Opponent is nil for byes and for results from before opponents
+// were recorded.
+type RatedResult struct {
+	DraftID      int64     `json:"draftId"`
+	Round        int       `json:"round"`
+	PlayedAt     time.Time `json:"playedAt"`
+	Opponent     *UserInfo `json:"opponent"`
+	Bye          bool      `json:"bye"`
+	GamesWon     int       `json:"gamesWon"`
+	GamesLost    int       `json:"gamesLost"`
+	GamesDrawn   int       `json:"gamesDrawn"`
+	RatingBefore float64   `json:"ratingBefore"`
+	RatingAfter  float64   `json:"ratingAfter"`
+}
+
+// HeadToHead is one player's record against another, turned into JSON and used for the REST API.
+// Records are from Player's side.
+type HeadToHead struct {
+	Player     UserInfo          `json:"player"`
+	Opponent   UserInfo          `json:"opponent"`
+	Wins       int               `json:"wins"`
+	Losses     int               `json:"losses"`
+	Draws      int               `json:"draws"`
+	GameWins   int               `json:"gameWins"`
+	GameLosses int               `json:"gameLosses"`
+	GameDraws  int               `json:"gameDraws"`
+	Drafts     []HeadToHeadDraft `json:"drafts"`
+}
+
+// HeadToHeadDraft is part of HeadToHead: the matches two players played in one draft and the decks
+// they played them with. Decks are left out until the draft's matches are over.
+type HeadToHeadDraft struct {
+	DraftID      int64             `json:"draftId"`
+	Name         string            `json:"name"`
+	Format       string            `json:"format"`
+	PlayerDeck   *HeadToHeadDeck   `json:"playerDeck,omitempty"`
+	OpponentDeck *HeadToHeadDeck   `json:"opponentDeck,omitempty"`
+	Matches      []HeadToHeadMatch `json:"matches"`
+}
+
+// HeadToHeadDeck is part of HeadToHead. Cards are the main deck if the deck was registered and the
+// whole pool otherwise.
+type HeadToHeadDeck struct {
+	Colors     string   `json:"colors"`
+	Registered bool     `json:"registered"`
+	Cards      []string `json:"cards"`
+}
+
+// HeadToHeadMatch is part of HeadToHead.
+type HeadToHeadMatch struct {
+	Round      int `json:"round"`
+	GamesWon   int `json:"gamesWon"`
+	GamesLost  int `json:"gamesLost"`
+	GamesDrawn int `json:"gamesDrawn"`
+}
+
+// DraftTiming is how long a draft and its picks took, turned into JSON and used for the REST API.
+// Only picks with timestamps are counted.
+type DraftTiming struct {
+	DraftID  int64 `json:"draftId"`
+	Finished bool  `json:"finished"`
+	// StartedAt is when the draft started, or if that isn't known, its first timed pick.
+	StartedAt         *time.Time     `json:"startedAt"`
+	LastPickAt        *time.Time     `json:"lastPickAt"`
+	DurationSeconds   float64        `json:"durationSeconds"`
+	TimedPicks        int            `json:"timedPicks"`
+	MedianPickSeconds float64        `json:"medianPickSeconds"`
+	SlowestHolds      []HoldJSON     `json:"slowestHolds"`
+	Players           []PlayerTiming `json:"players"`
+}
+
+// PlayerTiming is part of DraftTiming. Player is nil for empty seats.
+type PlayerTiming struct {
+	Player            *UserInfo `json:"player"`
+	Position          int       `json:"position"`
+	TimedPicks        int       `json:"timedPicks"`
+	MedianPickSeconds float64   `json:"medianPickSeconds"`
+	TotalPickSeconds  float64   `json:"totalPickSeconds"`
+	Slowest           *HoldJSON `json:"slowest"`
+}
+
+// HoldJSON is part of DraftTiming: how long a drafter held a pack before picking from it.
+type HoldJSON struct {
+	Player   *UserInfo `json:"player"`
+	Position int       `json:"position"`
+	Round    int       `json:"round"`
+	Pick     int       `json:"pick"`
+	At       time.Time `json:"at"`
+	Seconds  float64   `json:"seconds"`
+}
+
+// DraftProgress is where each drafter in a draft is, turned into JSON and used for the REST API.
+// Bottleneck is the seat holding up the draft, if one is.
+type DraftProgress struct {
+	DraftID    int64          `json:"draftId"`
+	State      string         `json:"state"`
+	Seats      []SeatProgress `json:"seats"`
+	Bottleneck *SeatProgress  `json:"bottleneck"`
+}
+
+// SeatProgress is part of DraftProgress. Player is nil for empty seats, and OldestQueuedAt is nil
+// if the seat has no packs queued or it isn't known when they arrived.
+type SeatProgress struct {
+	Player              *UserInfo  `json:"player"`
+	Position            int        `json:"position"`
+	Round               int        `json:"round"`
+	Picks               int        `json:"picks"`
+	PacksQueued         int        `json:"packsQueued"`
+	OldestQueuedAt      *time.Time `json:"oldestQueuedAt"`
+	OldestQueuedSeconds float64    `json:"oldestQueuedSeconds"`
+	Blocking            bool       `json:"blocking"`
+}
+
+// DraftUpdate is sent to clients watching a draft when it changes. Modified is the draft's latest
+// DraftModified value once the change was made.
+type DraftUpdate struct {
+	DraftID  int64  `json:"draftId"`
+	Type     string `json:"type"`
+	Modified int64  `json:"modified"`
 }
 
 // These structs are for receiving data from the client.
@@ -129,12 +398,51 @@ type PostedJoin struct {
 	Position int64 `json:"position,omitempty"`
 }
 
+// PostedWaitlist is JSON accepted from the client when a user joins or leaves a draft's waitlist.
+type PostedWaitlist struct {
+	ID    int64 `json:"id"`
+	Leave bool  `json:"leave,omitempty"`
+}
+
+// PostedLeave is JSON accepted from the client when a user gives up their seat.
+type PostedLeave struct {
+	ID   int64 `json:"id"`
+	User int64 `json:"user,omitempty"`
+}
+
 // PostedPref is JSON accepted from the client when a user changes their preferences.
 type PostedPref struct {
 	MtgoName   string         `json:"mtgoName"`
 	FormatPref UserFormatPref `json:"pref"`
 }
 
+// PostedResult is JSON accepted from the client to report, confirm or dispute a match result.
+// Round defaults to the current round. Wins, Losses and Draws are the games in the match from the point
+// of view of the user, or of User when the admin is settling a dispute.
+type PostedResult struct {
+	Round  int    `json:"round"`
+	Action string `json:"action"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Draws  int    `json:"draws"`
+	User   int64  `json:"user"`
+}
+
+// PostedSeason is JSON accepted from the admin to add a season. Times are RFC 3339, and EndAt can be
+// left empty for a season that hasn't got an end date yet.
+type PostedSeason struct {
+	Name    string `json:"name"`
+	StartAt string `json:"startAt"`
+	EndAt   string `json:"endAt"`
+}
+
+// PostedDeck is JSON accepted from the client when a user saves their deck.
+type PostedDeck struct {
+	MainDeck []int64        `json:"mainDeck"`
+	Basics   map[string]int `json:"basics"`
+	Lock     bool           `json:"lock"`
+}
+
 // PostedGetCardPack is JSON accepted from the client to request the pack for a scanned card.
 type PostedGetCardPack struct {
 	DraftID  int64  `json:"draftId"`
@@ -161,22 +469,12 @@ type PostedMakeDraft struct {
 	AssignPacks bool   `json:"assignPacks"`
 	PickTwo     bool   `json:"pickTwo"`
 	Seed        int    `json:"seed"`
-}
-
-// These structs are for exporting in bulk to .dek files.
-
-// BulkMTGOExport is used to bulk export .dek files for the admin.
-type BulkMTGOExport struct {
-	PlayerID int64
-	Username string
-	Deck     string
-}
-
-// NameAndQuantity is used in MTGO .dek exports.
-type NameAndQuantity struct {
-	Name     string
-	MTGO     string
-	Quantity int64
+	OpenAt      string `json:"openAt"`
+	StartAt     string `json:"startAt"`
+	Rounds      int    `json:"rounds"`
+	// SpectatorDelayPicks and SpectatorDelay hold back what spectators see; see makedraft.Settings.
+	SpectatorDelayPicks int    `json:"spectatorDelayPicks"`
+	SpectatorDelay      string `json:"spectatorDelay"`
 }
 
 // R38CardData is the JSON passed to the client for card data.
@@ -190,8 +488,11 @@ type R38CardData struct {
 // ScryfallCardData is more JSON passed to the client for card data.
 // Note that this does not describe everything that is in the data, just what we need
 type ScryfallCardData struct {
-	Name   string   `json:"name"`
-	Colors []string `json:"colors"`
+	Name            string   `json:"name"`
+	Colors          []string `json:"colors"`
+	Set             string   `json:"set"`
+	CollectorNumber string   `json:"collector_number"`
+	Rarity          string   `json:"rarity"`
 }
 
 // SetCardData is the JSON data needed to write tags for each card in a set.
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/picktiming"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// ServeAPIDraftTiming serves the /api/drafttiming/{draft} endpoint: how long the draft took and how
+// long each drafter held their packs.
+func ServeAPIDraftTiming(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/drafttiming/(\d+)`)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(getDraftTiming(draft))
+}
+
+func getDraftTiming(draft *schema.Draft) DraftTiming {
+	events := slices.Clone(draft.Events)
+	slices.SortFunc(events, func(a, b *schema.Event) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	var picks []picktiming.Pick
+	for _, event := range events {
+		pick := picktiming.Pick{
+			Position: event.Position,
+			Round:    event.Round,
+		}
+		if event.Pack != nil {
+			pick.Pack = event.Pack.Id
+		}
+		if isTimeSet(event.Timestamp) {
+			pick.At = event.Timestamp
+		}
+		picks = append(picks, pick)
+	}
+	var start time.Time
+	if isTimeSet(draft.StartedAt) {
+		start = draft.StartedAt
+	}
+	stats := picktiming.Analyze(start, picks)
+
+	seats := make(map[int]*schema.Seat)
+	for _, seat := range draft.Seats {
+		seats[seat.Position] = seat
+	}
+	timing := DraftTiming{
+		DraftID:           int64(draft.Id),
+		Finished:          lifecycle.State(draft.State).Finished(),
+		TimedPicks:        stats.Picks,
+		DurationSeconds:   stats.Duration.Seconds(),
+		MedianPickSeconds: stats.Median.Seconds(),
+		SlowestHolds:      []HoldJSON{},
+		Players:           []PlayerTiming{},
+	}
+	if !stats.Start.IsZero() {
+		timing.StartedAt = &stats.Start
+	}
+	if !stats.LastPick.IsZero() {
+		timing.LastPickAt = &stats.LastPick
+	}
+	for _, hold := range stats.Slowest {
+		timing.SlowestHolds = append(timing.SlowestHolds, holdToJSON(hold, seats[hold.Position]))
+	}
+	for _, player := range stats.Players {
+		playerTiming := PlayerTiming{
+			Position:          player.Position,
+			TimedPicks:        player.Picks,
+			MedianPickSeconds: player.Median.Seconds(),
+			TotalPickSeconds:  player.Total.Seconds(),
+		}
+		if seat := seats[player.Position]; seat != nil && seat.User != nil {
+			userInfo := makeUserInfo(seat.User)
+			playerTiming.Player = &userInfo
+		}
+		if player.Slowest != nil {
+			slowest := holdToJSON(*player.Slowest, seats[player.Position])
+			playerTiming.Slowest = &slowest
+		}
+		timing.Players = append(timing.Players, playerTiming)
+	}
+	return timing
+}
+
+func holdToJSON(hold picktiming.Hold, seat *schema.Seat) HoldJSON {
+	holdJSON := HoldJSON{
+		Position: hold.Position,
+		Round:    hold.Round,
+		Pick:     hold.Number,
+		At:       hold.At,
+		Seconds:  hold.Duration.Seconds(),
+	}
+	if seat != nil && seat.User != nil {
+		userInfo := makeUserInfo(seat.User)
+		holdJSON.Player = &userInfo
+	}
+	return holdJSON
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/pairings"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// draftRounds is how many rounds of Swiss are played after a draft.
+func draftRounds(draft *schema.Draft) int {
+	if draft.Rounds > 0 {
+		return draft.Rounds
+	}
+	return pairings.DefaultRounds(len(draftPlayers(draft)))
+}
+
+// draftPlayers lists the IDs of everyone in a draft in seat order.
+func draftPlayers(draft *schema.Draft) []uint64 {
+	seats := slices.Clone(draft.Seats)
+	slices.SortFunc(seats, func(a, b *schema.Seat) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	var players []uint64
+	for _, seat := range seats {
+		if seat.User != nil {
+			players = append(players, seat.User.Id)
+		}
+	}
+	return players
+}
+
+// draftUsers maps the IDs of everyone in a draft to their users.
+func draftUsers(draft *schema.Draft) map[uint64]*schema.User {
+	users := make(map[uint64]*schema.User)
+	for _, seat := range draft.Seats {
+		if seat.User != nil {
+			users[seat.User.Id] = seat.User
+		}
+	}
+	return users
+}
+
+// loadMatches reads a draft's pairings. A match only counts as reported once its result is confirmed.
+func loadMatches(ob *objectbox.ObjectBox, draft *schema.Draft) ([]pairings.Match, error) {
+	pairingRows, err := loadPairings(ob, draft)
+	if err != nil {
+		return nil, err
+	}
+	var matches []pairings.Match
+	for _, pairing := range pairingRows {
+		matches = append(matches, pairingToMatch(pairing))
+	}
+	return matches, nil
+}
+
+func loadPairings(ob *objectbox.ObjectBox, draft *schema.Draft) ([]*schema.Pairing, error) {
+	pairingRows, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Draft.Equals(draft.Id)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("error loading pairings for draft %d: %w", draft.Id, err)
+	}
+	return pairingRows, nil
+}
+
+func pairingToMatch(pairing *schema.Pairing) pairings.Match {
+	if pairing.Player2 == nil {
+		return pairings.NewBye(pairing.Round, pairing.Player1.Id)
+	}
+	match := pairings.Match{
+		Round:   pairing.Round,
+		Player1: pairing.Player1.Id,
+		Player2: pairing.Player2.Id,
+	}
+	if pairing.Status == ResultConfirmed {
+		match.Reported = true
+		match.Wins1 = pairing.Wins1
+		match.Wins2 = pairing.Wins2
+		match.Draws = pairing.Draws
+	}
+	return match
+}
+
+// pairNextRound pairs the round after the last one played in a draft, saves the pairings and posts them
+// to Discord. Byes are recorded as wins straight away.
+func pairNextRound(ob *objectbox.ObjectBox, draft *schema.Draft) error {
+	matches, err := loadMatches(ob, draft)
+	if err != nil {
+		return err
+	}
+	next, err := pairings.Pair(draftPlayers(draft), matches)
+	if err != nil {
+		return fmt.Errorf("error pairing draft %d: %w", draft.Id, err)
+	}
+
+	users := draftUsers(draft)
+	round := next[0].Round
+	for _, match := range next {
+		pairing := &schema.Pairing{
+			Draft:   draft,
+			Round:   round,
+			Player1: users[match.Player1],
+		}
+		if match.IsBye() {
+			pairing.Status = ResultConfirmed
+			pairing.Wins1 = match.Wins1
+			_, err = schema.BoxForResult(ob).Put(&schema.Result{
+				Draft:     draft,
+				Round:     round,
+				User:      users[match.Player1],
+				Win:       true,
+				GamesWon:  match.Wins1,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return fmt.Errorf("error recording bye: %w", err)
+			}
+		} else {
+			pairing.Player2 = users[match.Player2]
+		}
+		_, err = schema.BoxForPairing(ob).Put(pairing)
+		if err != nil {
+			return fmt.Errorf("error saving pairing: %w", err)
+		}
+	}
+
+	records := make(map[uint64]string)
+	if round > 1 {
+		for _, standing := range pairings.Standings(draftPlayers(draft), matches) {
+			records[standing.Player] = " (" +
+				formatScore(standing.MatchWins, standing.MatchLosses, standing.MatchDraws) + ")"
+		}
+	}
+	return PostPairings(ob, draft, round, formatPairings(next, users, records))
+}
+
+// formatPairings lists a round's matches for a Discord message, with each player's record so far.
+func formatPairings(matches []pairings.Match, users map[uint64]*schema.User, records map[uint64]string) string {
+	var lines []string
+	for _, match := range matches {
+		player1 := discordMention(users[match.Player1]) + records[match.Player1]
+		if match.IsBye() {
+			lines = append(lines, fmt.Sprintf("%s has a bye", player1))
+		} else {
+			player2 := discordMention(users[match.Player2]) + records[match.Player2]
+			lines = append(lines, fmt.Sprintf("%s vs %s", player1, player2))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// discordMention pings a user in a Discord message, or names them if they aren't on Discord.
+func discordMention(user *schema.User) string {
+	if len(user.DiscordId) > 0 {
+		return fmt.Sprintf("<@%s>", user.DiscordId)
+	}
+	return user.DiscordName
+}
+
+// ServeAPIStandings serves the /api/standings/{draft} endpoint: every player's record and tiebreakers,
+// and the pairings for each round so far.
+func ServeAPIStandings(w http.ResponseWriter, r *http.Request, _ int64, ob *objectbox.ObjectBox) error {
+	draft, err := getDraftFromPath(ob, r, `/api/standings/(\d+)`)
+	if err != nil {
+		return err
+	}
+	standings, err := getStandings(ob, draft)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(standings)
+}
+
+func getStandings(ob *objectbox.ObjectBox, draft *schema.Draft) (Standings, error) {
+	pairingRows, err := loadPairings(ob, draft)
+	if err != nil {
+		return Standings{}, err
+	}
+	var matches []pairings.Match
+	for _, pairing := range pairingRows {
+		matches = append(matches, pairingToMatch(pairing))
+	}
+	users := draftUsers(draft)
+	currentRound := pairings.LastRound(matches)
+	standings := Standings{
+		DraftID:      int64(draft.Id),
+		Rounds:       draftRounds(draft),
+		CurrentRound: currentRound,
+		Standings:    []PlayerStanding{},
+		Pairings:     []RoundPairings{},
+	}
+
+	currentOpponents := make(map[uint64]uint64)
+	for _, pairing := range pairingRows {
+		for len(standings.Pairings) < pairing.Round {
+			standings.Pairings = append(standings.Pairings, RoundPairings{
+				Round:   len(standings.Pairings) + 1,
+				Matches: []MatchJSON{},
+			})
+		}
+		if pairing.Round == currentRound && pairing.Player2 != nil {
+			currentOpponents[pairing.Player1.Id] = pairing.Player2.Id
+			currentOpponents[pairing.Player2.Id] = pairing.Player1.Id
+		}
+		round := &standings.Pairings[pairing.Round-1]
+		round.Matches = append(round.Matches, pairingToJSON(pairing))
+	}
+
+	for i, standing := range pairings.Standings(draftPlayers(draft), matches) {
+		playerStanding := PlayerStanding{
+			Rank:                i + 1,
+			Player:              makeUserInfo(users[standing.Player]),
+			Points:              standing.Points,
+			MatchWins:           standing.MatchWins,
+			MatchLosses:         standing.MatchLosses,
+			MatchDraws:          standing.MatchDraws,
+			GameWins:            standing.GameWins,
+			GameLosses:          standing.GameLosses,
+			GameDraws:           standing.GameDraws,
+			Byes:                standing.Byes,
+			MatchWinPct:         standing.MatchWinPct,
+			GameWinPct:          standing.GameWinPct,
+			OpponentMatchWinPct: standing.OpponentMatchWinPct,
+			OpponentGameWinPct:  standing.OpponentGameWinPct,
+		}
+		if opponent, ok := currentOpponents[standing.Player]; ok {
+			opponentInfo := makeUserInfo(users[opponent])
+			playerStanding.CurrentOpponent = &opponentInfo
+		}
+		standings.Standings = append(standings.Standings, playerStanding)
+	}
+	return standings, nil
+}
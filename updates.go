@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// Types of DraftUpdate.
+const (
+	DraftUpdatePick   = "pick"
+	DraftUpdateUndo   = "undo"
+	DraftUpdateJoin   = "join"
+	DraftUpdateLeave  = "leave"
+	DraftUpdateResult = "result"
+	DraftUpdateState  = "state"
+)
+
+// draftUpdateKeepAlive is how often an idle update stream sends a comment to keep proxies from
+// closing it.
+const draftUpdateKeepAlive = 30 * time.Second
+
+// draftUpdateBuffer is how many updates a slow watcher can fall behind before further ones are
+// dropped. Updates only tell clients to fetch the draft again, so dropping some is harmless.
+const draftUpdateBuffer = 16
+
+// draftUpdateHub hands out updates to the clients watching each draft. Updates are queued while a
+// write transaction runs and only sent once it commits, so clients never fetch a draft before the
+// change they were told about is visible.
+type draftUpdateHub struct {
+	mu       sync.Mutex
+	watchers map[int64]map[chan DraftUpdate]bool
+	pending  []DraftUpdate
+	// txMu is held from the start of a write transaction until its updates are flushed or discarded,
+	// so pending never mixes updates from two transactions.
+	txMu sync.Mutex
+}
+
+var draftUpdates = &draftUpdateHub{
+	watchers: make(map[int64]map[chan DraftUpdate]bool),
+}
+
+// watch starts sending a draft's updates to the returned channel until stop is called.
+func (h *draftUpdateHub) watch(draftID int64) (updates chan DraftUpdate, stop func()) {
+	updates = make(chan DraftUpdate, draftUpdateBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watchers[draftID] == nil {
+		h.watchers[draftID] = make(map[chan DraftUpdate]bool)
+	}
+	h.watchers[draftID][updates] = true
+	return updates, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.watchers[draftID], updates)
+		if len(h.watchers[draftID]) == 0 {
+			delete(h.watchers, draftID)
+		}
+	}
+}
+
+// queue holds an update until the current write transaction commits.
+func (h *draftUpdateHub) queue(update DraftUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending = append(h.pending, update)
+}
+
+// discard drops the updates from a write transaction that was rolled back.
+func (h *draftUpdateHub) discard() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending = nil
+}
+
+// flush sends the updates from a committed write transaction to everyone watching.
+func (h *draftUpdateHub) flush() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, update := range h.pending {
+		for watcher := range h.watchers[update.DraftID] {
+			select {
+			case watcher <- update:
+			default:
+				log.Printf("dropping %s update for a slow watcher of draft %d", update.Type, update.DraftID)
+			}
+		}
+	}
+	h.pending = nil
+}
+
+// runInWriteTx runs fn in a write transaction and sends any draft updates it queued once the
+// transaction commits, or drops them if it's rolled back.
+func runInWriteTx(ob *objectbox.ObjectBox, fn func() error) error {
+	draftUpdates.txMu.Lock()
+	defer draftUpdates.txMu.Unlock()
+	err := ob.RunInWriteTx(fn)
+	if err != nil {
+		draftUpdates.discard()
+		return err
+	}
+	draftUpdates.flush()
+	return nil
+}
+
+// queueDraftUpdate tells the draft's watchers that it changed, once the current write transaction
+// commits.
+func queueDraftUpdate(draft *schema.Draft, updateType string) {
+	draftUpdates.queue(DraftUpdate{
+		DraftID:  int64(draft.Id),
+		Type:     updateType,
+		Modified: int64(draftModified(draft)),
+	})
+}
+
+// queueDraftStateUpdateHook tells a draft's watchers that it moved to a new lifecycle state.
+func queueDraftStateUpdateHook(_ *objectbox.ObjectBox, draft *schema.Draft, _ lifecycle.Transition) error {
+	queueDraftUpdate(draft, DraftUpdateState)
+	return nil
+}
+
+// ServeDraftUpdates serves the /api/draftupdates/{draft} endpoint: a stream of server-sent events,
+// one per change to the draft. Updates don't include any of the draft itself, so anyone may watch;
+// clients fetch /api/draft/ again to see what changed.
+// It isn't registered with addHandler because it stays open far longer than a transaction should.
+func ServeDraftUpdates(ob *objectbox.ObjectBox) http.Handler {
+	re := regexp.MustCompile(`/api/draftupdates/(\d+)`)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parseResult := re.FindStringSubmatch(r.URL.Path)
+		if parseResult == nil {
+			http.Error(w, "bad api url", http.StatusNotFound)
+			return
+		}
+		draftID, err := strconv.ParseInt(parseResult[1], 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("bad api url: %s", err.Error()), http.StatusNotFound)
+			return
+		}
+		var draft *schema.Draft
+		err = ob.RunInReadTx(func() error {
+			var err error
+			draft, err = schema.BoxForDraft(ob).Get(uint64(draftID))
+			return err
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if draft == nil {
+			http.Error(w, fmt.Sprintf("couldn't find draft %d", draftID), http.StatusNotFound)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming isn't supported", http.StatusInternalServerError)
+			return
+		}
+
+		updates, stop := draftUpdates.watch(draftID)
+		defer stop()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		// Let the client know it's watching, so it can fetch the draft without missing a change.
+		_, err = fmt.Fprintf(w, ": watching draft %d\n\n", draftID)
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+
+		keepAlive := time.NewTicker(draftUpdateKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepAlive.C:
+				_, err = fmt.Fprint(w, ": keepalive\n\n")
+			case update := <-updates:
+				var data []byte
+				data, err = json.Marshal(update)
+				if err == nil {
+					_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", update.Type, data)
+				}
+			}
+			if err != nil {
+				log.Printf("error streaming updates for draft %d: %s", draftID, err.Error())
+				return
+			}
+			flusher.Flush()
+		}
+	})
+}
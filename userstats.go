@@ -0,0 +1,268 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/picklog"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// mostPickedCards is how many cards UserStats lists in MostPicked.
+const mostPickedCards = 10
+
+// colorOrder is the order colors are counted and named in.
+var colorOrder = []string{"W", "U", "B", "R", "G"}
+
+// ServeAPIUserStats serves the /api/userstats endpoint. It describes the current user, or the user in
+// the user query parameter. Other users' stats leave out drafts that are still being picked.
+func ServeAPIUserStats(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	statsUserID := uint64(userID)
+	if userParam := r.URL.Query().Get("user"); userParam != "" {
+		var err error
+		statsUserID, err = strconv.ParseUint(userParam, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad user id: %w", err)
+		}
+	}
+	user, err := schema.BoxForUser(ob).Get(statsUserID)
+	if err != nil {
+		return fmt.Errorf("error loading user %d: %w", statsUserID, err)
+	}
+	if user == nil {
+		return fmt.Errorf("no user %d", statsUserID)
+	}
+
+	userStats, err := getUserStats(ob, user, statsUserID != uint64(userID))
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(userStats)
+}
+
+// getUserStats works out a user's stats from the picks, decks and results of every draft they've sat in.
+// Public stats skip drafts that are still being picked, so nobody can see what someone else is taking.
+func getUserStats(ob *objectbox.ObjectBox, user *schema.User, public bool) (UserStats, error) {
+	userStats := UserStats{
+		Player:          makeUserInfo(user),
+		DraftedColors:   make([]int, len(colorOrder)+1),
+		MatchesByColors: make(map[string]MatchRecord),
+		MatchesByFormat: make(map[string]MatchRecord),
+		MostPicked:      []CardCount{},
+		FirstPickColors: make([]int, len(colorOrder)+1),
+		History:         []UserDraftStats{},
+	}
+
+	drafts, err := schema.BoxForDraft(ob).Query(
+		schema.Draft_.Seats.Link(schema.Seat_.User.Equals(user.Id)),
+	).Find()
+	if err != nil {
+		return userStats, fmt.Errorf("error loading drafts for user %d: %w", user.Id, err)
+	}
+	slices.SortFunc(drafts, func(a, b *schema.Draft) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	results, err := schema.BoxForResult(ob).Query(schema.Result_.User.Equals(user.Id)).Find()
+	if err != nil {
+		return userStats, fmt.Errorf("error loading results for user %d: %w", user.Id, err)
+	}
+	byes, err := loadByes(ob, user)
+	if err != nil {
+		return userStats, err
+	}
+	draftResults := make(map[uint64][]*schema.Result)
+	for _, result := range results {
+		if result.Draft != nil {
+			draftResults[result.Draft.Id] = append(draftResults[result.Draft.Id], result)
+		}
+	}
+
+	pickCounts := make(map[string]int)
+	rarePickTotal := 0
+	for _, draft := range drafts {
+		finished := lifecycle.State(draft.State).Finished()
+		if !draft.Archived {
+			if finished {
+				userStats.CompletedDrafts++
+			} else {
+				userStats.ActiveDrafts++
+			}
+		}
+		if public && !finished {
+			continue
+		}
+		seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+			return seat.User != nil && seat.User.Id == user.Id
+		})
+		if seatIndex == -1 {
+			continue
+		}
+		seat := draft.Seats[seatIndex]
+
+		deck, err := getDeck(ob, seat)
+		if err != nil {
+			return userStats, fmt.Errorf("error loading deck for seat %d: %w", seat.Id, err)
+		}
+		playedCards := seat.PickedCards
+		// Others only see what a registered deck held once every match has been played.
+		if deck != nil && (!public || lifecycle.State(draft.State).Concluded()) {
+			playedCards = deck.MainDeck
+		}
+		history := UserDraftStats{
+			DraftID: int64(draft.Id),
+			Name:    draft.Name,
+			Format:  draft.Format,
+			State:   draft.State,
+			Colors:  mainColors(playedCards),
+			Picks:   len(seat.PickedCards),
+		}
+
+		if !draft.Archived {
+			for _, card := range seat.PickedCards {
+				countColors(userStats.DraftedColors, cardData(card).Scryfall.Colors)
+			}
+		}
+		for _, pick := range picklog.Reconstruct(draft) {
+			if pick.Seat != seat {
+				continue
+			}
+			data := cardData(pick.Card)
+			pickCounts[data.Scryfall.Name]++
+			if data.Scryfall.Rarity == "rare" || data.Scryfall.Rarity == "mythic" {
+				userStats.RaresPicked++
+				rarePickTotal += pick.Number
+			}
+			if pick.Number == 1 {
+				countColors(userStats.FirstPickColors, data.Scryfall.Colors)
+			}
+		}
+
+		var record MatchRecord
+		for _, result := range draftResults[draft.Id] {
+			if byes[resultKey(draft.Id, result.Round)] {
+				history.Byes++
+				continue
+			}
+			addResult(&record, result)
+		}
+		history.Wins, history.Losses, history.Draws = record.Wins, record.Losses, record.Draws
+		history.Trophy = history.Wins+history.Byes > 0 && history.Losses == 0 && history.Draws == 0 &&
+			history.Wins+history.Byes >= draftRounds(draft)
+		if history.Trophy {
+			userStats.Trophies++
+		}
+		userStats.Matches = mergeRecords(userStats.Matches, record)
+		if record.Wins+record.Losses+record.Draws > 0 {
+			userStats.MatchesByColors[history.Colors] = mergeRecords(userStats.MatchesByColors[history.Colors], record)
+			userStats.MatchesByFormat[draft.Format] = mergeRecords(userStats.MatchesByFormat[draft.Format], record)
+		}
+		userStats.History = append(userStats.History, history)
+	}
+
+	if userStats.RaresPicked > 0 {
+		userStats.RareAveragePick = float64(rarePickTotal) / float64(userStats.RaresPicked)
+	}
+	for name, count := range pickCounts {
+		userStats.MostPicked = append(userStats.MostPicked, CardCount{Name: name, Count: count})
+	}
+	slices.SortFunc(userStats.MostPicked, func(a, b CardCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	userStats.MostPicked = userStats.MostPicked[:min(len(userStats.MostPicked), mostPickedCards)]
+	return userStats, nil
+}
+
+// loadByes finds the rounds a user had a bye in, keyed by resultKey.
+func loadByes(ob *objectbox.ObjectBox, user *schema.User) (map[string]bool, error) {
+	pairingRows, err := schema.BoxForPairing(ob).Query(schema.Pairing_.Player1.Equals(user.Id)).Find()
+	if err != nil {
+		return nil, fmt.Errorf("error loading pairings for user %d: %w", user.Id, err)
+	}
+	byes := make(map[string]bool)
+	for _, pairing := range pairingRows {
+		if pairing.Player2 == nil && pairing.Draft != nil {
+			byes[resultKey(pairing.Draft.Id, pairing.Round)] = true
+		}
+	}
+	return byes, nil
+}
+
+func resultKey(draftID uint64, round int) string {
+	return fmt.Sprintf("%d/%d", draftID, round)
+}
+
+func addResult(record *MatchRecord, result *schema.Result) {
+	switch {
+	case result.GamesWon > result.GamesLost:
+		record.Wins++
+	case result.GamesWon < result.GamesLost:
+		record.Losses++
+	default:
+		record.Draws++
+	}
+}
+
+// mergeRecords adds two records together and works out the combined win rate.
+func mergeRecords(a, b MatchRecord) MatchRecord {
+	merged := MatchRecord{
+		Wins:   a.Wins + b.Wins,
+		Losses: a.Losses + b.Losses,
+		Draws:  a.Draws + b.Draws,
+	}
+	if played := merged.Wins + merged.Losses + merged.Draws; played > 0 {
+		merged.WinRate = float64(merged.Wins) / float64(played)
+	}
+	return merged
+}
+
+// cardData parses a card's data, leaving it empty if the data can't be read.
+func cardData(card *schema.Card) R38CardData {
+	var data R38CardData
+	_ = json.Unmarshal([]byte(card.Data), &data)
+	return data
+}
+
+// countColors adds a card's colors to a histogram of white, blue, black, red, green and colorless.
+func countColors(histogram []int, colors []string) {
+	for _, color := range colors {
+		if i := slices.Index(colorOrder, color); i != -1 {
+			histogram[i]++
+		}
+	}
+	if len(colors) == 0 {
+		histogram[len(colorOrder)]++
+	}
+}
+
+// mainColors names the one or two colors with the most cards, in WUBRG order, or "C" if every card
+// is colorless.
+func mainColors(cards []*schema.Card) string {
+	histogram := make([]int, len(colorOrder)+1)
+	for _, card := range cards {
+		countColors(histogram, cardData(card).Scryfall.Colors)
+	}
+	ranked := slices.Clone(colorOrder)
+	slices.SortStableFunc(ranked, func(a, b string) int {
+		return cmp.Compare(histogram[slices.Index(colorOrder, b)], histogram[slices.Index(colorOrder, a)])
+	})
+	var main []string
+	for _, color := range ranked[:2] {
+		if histogram[slices.Index(colorOrder, color)] > 0 {
+			main = append(main, color)
+		}
+	}
+	if len(main) == 0 {
+		return "C"
+	}
+	slices.SortFunc(main, func(a, b string) int {
+		return cmp.Compare(slices.Index(colorOrder, a), slices.Index(colorOrder, b))
+	})
+	return strings.Join(main, "")
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/walkingeyerobot/r38/lifecycle"
+	"github.com/walkingeyerobot/r38/schema"
+)
+
+// WaitlistReservationWindow is how long a promoted waitlisted user has to confirm their seat.
+const WaitlistReservationWindow = 12 * time.Hour
+
+// ServeAPIWaitlist serves the /api/waitlist endpoint.
+func ServeAPIWaitlist(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if r.Method != "POST" {
+		return MethodNotAllowedError
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading post body: %w", err)
+	}
+	var posted PostedWaitlist
+	err = json.Unmarshal(bodyBytes, &posted)
+	if err != nil {
+		return fmt.Errorf("error parsing post body: %w", err)
+	}
+
+	if posted.Leave {
+		err = doLeaveWaitlist(ob, userID, posted.ID)
+	} else {
+		err = doJoinWaitlist(ob, userID, posted.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("error updating waitlist for draft %d: %w", posted.ID, err)
+	}
+
+	draftInfo, err := GetDraftListEntry(userID, ob, posted.ID)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(draftInfo)
+}
+
+// doJoinWaitlist adds a user to the end of a full draft's waitlist.
+func doJoinWaitlist(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+	if err != nil {
+		return err
+	}
+	err = checkDraftState(draft, "join the waitlist", lifecycle.State.Joinable)
+	if err != nil {
+		return err
+	}
+
+	for _, seat := range draft.Seats {
+		if seat.User != nil && seat.User.Id == uint64(userId) {
+			return fmt.Errorf("user %d already joined %d", userId, draftId)
+		}
+		if seat.User == nil && seat.ReservedUser != nil && seat.ReservedUser.Id == uint64(userId) {
+			return fmt.Errorf("user %d already has a seat reserved in %d", userId, draftId)
+		}
+		if seat.User == nil && seat.ReservedUser == nil {
+			return fmt.Errorf("draft %d still has open seats", draftId)
+		}
+	}
+	if slices.ContainsFunc(draft.Waitlist, func(entry *schema.WaitlistEntry) bool {
+		return entry.User != nil && entry.User.Id == uint64(userId)
+	}) {
+		return fmt.Errorf("user %d already on the waitlist for %d", userId, draftId)
+	}
+
+	user, err := schema.BoxForUser(ob).Get(uint64(userId))
+	if err != nil {
+		return err
+	}
+	draft.Waitlist = append(draft.Waitlist, &schema.WaitlistEntry{
+		User:      user,
+		Timestamp: time.Now(),
+	})
+	_, err = schema.BoxForDraft(ob).Put(draft)
+	return err
+}
+
+// doLeaveWaitlist takes a user off a draft's waitlist.
+func doLeaveWaitlist(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+	if err != nil {
+		return err
+	}
+
+	removed, err := removeFromWaitlist(ob, draft, userId)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("user %d isn't on the waitlist for %d", userId, draftId)
+	}
+	return nil
+}
+
+// removeFromWaitlist takes a user off a draft's waitlist if they're on it, saving the draft.
+func removeFromWaitlist(ob *objectbox.ObjectBox, draft *schema.Draft, userId int64) (bool, error) {
+	entryIndex := slices.IndexFunc(draft.Waitlist, func(entry *schema.WaitlistEntry) bool {
+		return entry.User != nil && entry.User.Id == uint64(userId)
+	})
+	if entryIndex == -1 {
+		return false, nil
+	}
+	entry := draft.Waitlist[entryIndex]
+	draft.Waitlist = slices.Delete(draft.Waitlist, entryIndex, entryIndex+1)
+	_, err := schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		return false, err
+	}
+	return true, schema.BoxForWaitlistEntry(ob).Remove(entry)
+}
+
+// ServeAPILeave serves the /api/leave endpoint.
+// The admin may remove any player, which frees their seat for the waitlist.
+func ServeAPILeave(w http.ResponseWriter, r *http.Request, userID int64, ob *objectbox.ObjectBox) error {
+	if r.Method != "POST" {
+		return MethodNotAllowedError
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading post body: %w", err)
+	}
+	var posted PostedLeave
+	err = json.Unmarshal(bodyBytes, &posted)
+	if err != nil {
+		return fmt.Errorf("error parsing post body: %w", err)
+	}
+
+	leavingUserID := userID
+	if posted.User != 0 && posted.User != userID {
+		if userID != 1 {
+			return fmt.Errorf("not allowed")
+		}
+		leavingUserID = posted.User
+	}
+
+	err = doLeave(ob, leavingUserID, posted.ID)
+	if err != nil {
+		return fmt.Errorf("error leaving draft %d: %w", posted.ID, err)
+	}
+
+	draftJSON, err := GetFilteredJSON(ob, posted.ID, userID)
+	if err != nil {
+		return fmt.Errorf("error getting json: %w", err)
+	}
+
+	_, err = fmt.Fprint(w, draftJSON)
+	return err
+}
+
+// doLeave gives up a user's seat in a draft they haven't picked in yet.
+func doLeave(ob *objectbox.ObjectBox, userId int64, draftId int64) error {
+	draft, err := schema.BoxForDraft(ob).Get(uint64(draftId))
+	if err != nil {
+		return err
+	}
+	err = checkDraftState(draft, "leave", lifecycle.State.Joinable)
+	if err != nil {
+		return err
+	}
+
+	seatIndex := slices.IndexFunc(draft.Seats, func(seat *schema.Seat) bool {
+		return seat.User != nil && seat.User.Id == uint64(userId)
+	})
+	if seatIndex == -1 {
+		return fmt.Errorf("user %d not in draft %d", userId, draftId)
+	}
+	seat := draft.Seats[seatIndex]
+	if len(seat.PickedCards) > 0 {
+		return fmt.Errorf("user %d has already picked in draft %d", userId, draftId)
+	}
+	user := seat.User
+	seat.User = nil
+	seat.ReservedUser = nil
+	queueDraftUpdate(draft, DraftUpdateLeave)
+
+	if dg != nil && draft.SpectatorChannelId != "" && user.DiscordId != "" {
+		err = dg.ChannelPermissionDelete(draft.SpectatorChannelId, user.DiscordId)
+		if err != nil {
+			log.Printf("error unlocking spectator channel for user %s: %s", user.DiscordId, err.Error())
+		}
+	} else {
+		ignoredDiscordCalls = append(ignoredDiscordCalls, DiscordCall{
+			Type:      "unlockChannel",
+			ChannelId: draft.SpectatorChannelId,
+			Message:   user.DiscordId,
+		})
+	}
+
+	return promoteFromWaitlist(ob, draft, seat)
+}
+
+// promoteFromWaitlist reserves a freed seat for the first user on the draft's waitlist and
+// messages them with the deadline to confirm. If nobody is waiting, the seat is left open.
+// The seat is saved either way.
+func promoteFromWaitlist(ob *objectbox.ObjectBox, draft *schema.Draft, seat *schema.Seat) error {
+	seat.ReservedUser = nil
+	seat.ReservedUntil = time.Time{}
+
+	slices.SortStableFunc(draft.Waitlist, func(a, b *schema.WaitlistEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	var promoted *schema.WaitlistEntry
+	for len(draft.Waitlist) > 0 && promoted == nil {
+		entry := draft.Waitlist[0]
+		draft.Waitlist = draft.Waitlist[1:]
+		err := schema.BoxForWaitlistEntry(ob).Remove(entry)
+		if err != nil {
+			return err
+		}
+		if entry.User == nil {
+			continue
+		}
+		// Users who found a seat some other way since joining the waitlist don't need another one.
+		if !slices.ContainsFunc(draft.Seats, func(s *schema.Seat) bool {
+			return (s.User != nil && s.User.Id == entry.User.Id) ||
+				(s.ReservedUser != nil && s.ReservedUser.Id == entry.User.Id)
+		}) {
+			promoted = entry
+		}
+	}
+	_, err := schema.BoxForDraft(ob).Put(draft)
+	if err != nil {
+		return err
+	}
+
+	if promoted != nil {
+		seat.ReservedUser = promoted.User
+		seat.ReservedUntil = time.Now().Add(WaitlistReservationWindow)
+		log.Printf("promoting user %d from the waitlist to seat %d in draft %d",
+			promoted.User.Id, seat.Id, draft.Id)
+	}
+	_, err = schema.BoxForSeat(ob).Put(seat)
+	if err != nil {
+		return err
+	}
+
+	if promoted != nil && promoted.User.DiscordId != "" {
+		err = DiscordDirectMessage(promoted.User.DiscordId,
+			fmt.Sprintf("A seat opened up in *%s*! Join by <t:%d:f> to claim it: <https://draftcu.be/draft/%d>",
+				draft.Name, seat.ReservedUntil.Unix(), draft.Id))
+		if err != nil {
+			log.Printf("error messaging user %d about their waitlist promotion: %s", promoted.User.Id, err.Error())
+		}
+	}
+	return nil
+}
+
+// ExpireWaitlistReservations passes unclaimed waitlist reservations on to the next user in line.
+// It is run periodically by the scheduler in main.
+func ExpireWaitlistReservations(ob *objectbox.ObjectBox) error {
+	err := runInWriteTx(ob, func() error {
+		drafts, err := schema.BoxForDraft(ob).Query(objectbox.Any(
+			schema.Draft_.State.Equals(string(lifecycle.Open), true),
+			schema.Draft_.State.Equals(string(lifecycle.Drafting), true),
+		)).Find()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, draft := range drafts {
+			for _, seat := range draft.Seats {
+				if seat.User != nil || seat.ReservedUser == nil ||
+					!isTimeSet(seat.ReservedUntil) || seat.ReservedUntil.After(now) {
+					continue
+				}
+				log.Printf("user %d's reservation for seat %d in draft %d expired",
+					seat.ReservedUser.Id, seat.Id, draft.Id)
+				err = recordSkip(ob, int64(seat.ReservedUser.Id), int64(draft.Id))
+				if err != nil {
+					return err
+				}
+				err = promoteFromWaitlist(ob, draft, seat)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error expiring waitlist reservations: %w", err)
+	}
+	return nil
+}
+
+// DiscordDirectMessage sends a private message to a discord user.
+func DiscordDirectMessage(discordId string, message string) error {
+	if dg != nil {
+		channel, err := dg.UserChannelCreate(discordId)
+		if err != nil {
+			return err
+		}
+		_, err = dg.ChannelMessageSend(channel.ID, message)
+		return err
+	} else {
+		ignoredDiscordCalls = append(ignoredDiscordCalls, DiscordCall{
+			Type:      "directMessage",
+			ChannelId: discordId,
+			Message:   message,
+		})
+	}
+	return nil
+}